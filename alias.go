@@ -0,0 +1,32 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Alias makes the interface type the first target points to resolve through the default binding
+// of the concrete type the second target points to, e.g. Alias(new(Database), new(*postgresDB)).
+// The alias delegates on every resolution, so it returns the concrete binding's singleton.
+func (c *Container) Alias(interfaceTarget, concreteTarget interface{}) error {
+	interfaceType, concreteType := reflect.TypeOf(interfaceTarget), reflect.TypeOf(concreteTarget)
+	if interfaceType == nil || interfaceType.Kind() != reflect.Ptr || concreteType == nil || concreteType.Kind() != reflect.Ptr {
+		return ErrNotAPointer
+	}
+	interfaceType, concreteType = interfaceType.Elem(), concreteType.Elem()
+
+	if interfaceType.Kind() != reflect.Interface {
+		return fmt.Errorf("cannot alias %s: not an interface type", interfaceType)
+	}
+	if !concreteType.Implements(interfaceType) {
+		return fmt.Errorf("cannot alias %s to %s: %s does not implement %s", interfaceType, concreteType, concreteType, interfaceType)
+	}
+
+	funcType := reflect.FuncOf([]reflect.Type{concreteType}, []reflect.Type{interfaceType}, false)
+	resolver := reflect.MakeFunc(funcType, func(arguments []reflect.Value) []reflect.Value {
+		instance := reflect.New(interfaceType).Elem()
+		instance.Set(arguments[0])
+		return []reflect.Value{instance}
+	})
+	return c.bind(resolver.Interface(), &bindConfig{lazy: true})
+}
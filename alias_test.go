@@ -0,0 +1,56 @@
+package di
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type postgresDB struct {
+	mockDatabase
+}
+
+func TestContainer_Alias(t *testing.T) {
+	t.Run("interface resolves to the concrete singleton", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func() *postgresDB {
+			return &postgresDB{}
+		})
+		require.NoError(t, err)
+		require.NoError(t, container.Alias(new(Database), new(*postgresDB)))
+
+		var concrete *postgresDB
+		require.NoError(t, container.Resolve(&concrete))
+
+		var db Database
+		require.NoError(t, container.Resolve(&db))
+		assert.Same(t, concrete, db)
+
+		var again Database
+		require.NoError(t, container.Resolve(&again))
+		assert.Same(t, concrete, again)
+	})
+
+	t.Run("concrete type does not implement the interface", func(t *testing.T) {
+		container := New()
+
+		err := container.Alias(new(UserService), new(*postgresDB))
+		assert.EqualError(t, err, "cannot alias di.UserService to *di.postgresDB: *di.postgresDB does not implement di.UserService")
+	})
+
+	t.Run("target is not an interface", func(t *testing.T) {
+		container := New()
+
+		err := container.Alias(new(*mockDatabase), new(*postgresDB))
+		assert.EqualError(t, err, "cannot alias *di.mockDatabase: not an interface type")
+	})
+
+	t.Run("missing concrete binding", func(t *testing.T) {
+		container := New()
+		require.NoError(t, container.Alias(new(Database), new(*postgresDB)))
+
+		var db Database
+		assert.ErrorIs(t, container.Resolve(&db), ErrBindingNotFound)
+	})
+}
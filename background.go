@@ -0,0 +1,58 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// WithBackgroundEager makes the binding a singleton constructed in a separate goroutine right after
+// it is registered, so slow initializations do not block Bind. Resolutions made meanwhile wait for
+// the construction to finish. Its dependencies must be registered beforehand; use ConstructionError
+// to await the outcome.
+func WithBackgroundEager() BindOption {
+	return func(config *bindConfig) {
+		config.background = true
+		config.lazy = true
+		config.singleton = true
+	}
+}
+
+// constructInBackground constructs the singleton instance and records the outcome for ConstructionError.
+func (b *binding) constructInBackground(c *Container) {
+	_, b.readyErr = b.obtain(c, c.begin(context.Background()), lifetimeDefault)
+	close(b.ready)
+}
+
+// ConstructionError returns a channel that delivers the error of the background construction of the
+// default binding for the type the target points to, or nil if it succeeded, once it is done. For bindings
+// without a background construction it delivers nil right away, or an error if the binding does not exist.
+func (c *Container) ConstructionError(target interface{}) <-chan error {
+	result := make(chan error, 1)
+
+	targetType := reflect.TypeOf(target)
+	if targetType == nil || targetType.Kind() != reflect.Ptr {
+		result <- ErrNotAPointer
+		return result
+	}
+
+	b, err := c.lookup(targetType.Elem(), "")
+	if err != nil {
+		result <- err
+		return result
+	}
+	if b == nil {
+		result <- fmt.Errorf("%w for type %s with name ''", ErrBindingNotFound, targetType.Elem())
+		return result
+	}
+	if b.ready == nil {
+		result <- nil
+		return result
+	}
+
+	go func() {
+		<-b.ready
+		result <- b.readyErr
+	}()
+	return result
+}
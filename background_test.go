@@ -0,0 +1,51 @@
+package di
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer_ConstructionError(t *testing.T) {
+	t.Run("delivers the factory error", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func() (Database, error) {
+			time.Sleep(10 * time.Millisecond)
+			return nil, errors.New("connection refused")
+		}, WithBackgroundEager())
+		require.NoError(t, err)
+
+		select {
+		case err := <-container.ConstructionError(new(Database)):
+			assert.EqualError(t, err, "connection refused")
+		case <-time.After(time.Second):
+			t.Fatal("background construction did not finish")
+		}
+	})
+
+	t.Run("delivers nil once constructed", func(t *testing.T) {
+		container := New()
+		constructed := 0
+		err := container.Bind(func() Database {
+			constructed++
+			return &mockDatabase{}
+		}, WithBackgroundEager())
+		require.NoError(t, err)
+
+		require.NoError(t, <-container.ConstructionError(new(Database)))
+		var db Database
+		require.NoError(t, container.Resolve(&db))
+		assert.Equal(t, 1, constructed)
+	})
+
+	t.Run("bindings without background construction", func(t *testing.T) {
+		container := New()
+		require.NoError(t, container.Bind(func() Database { return &mockDatabase{} }))
+
+		assert.NoError(t, <-container.ConstructionError(new(Database)))
+		assert.ErrorIs(t, <-container.ConstructionError(new(Logger)), ErrBindingNotFound)
+	})
+}
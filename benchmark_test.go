@@ -0,0 +1,114 @@
+package di
+
+import (
+	"testing"
+)
+
+func newBenchmarkContainer(b *testing.B) *Container {
+	container := New()
+
+	if err := container.Bind(func() Database {
+		return &mockDatabase{}
+	}); err != nil {
+		b.Fatal(err)
+	}
+
+	if err := container.Bind(func(db Database) UserService {
+		return &userServiceImpl{db: db}
+	}); err != nil {
+		b.Fatal(err)
+	}
+
+	return container
+}
+
+func BenchmarkResolveSingleton(b *testing.B) {
+	container := newBenchmarkContainer(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var userService UserService
+		if err := container.Resolve(&userService); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkResolveSingletonParallel measures contention on a hot cached singleton.
+// Cached instances are read without locking, so throughput should scale with GOMAXPROCS.
+func BenchmarkResolveSingletonParallel(b *testing.B) {
+	container := newBenchmarkContainer(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			var userService UserService
+			if err := container.Resolve(&userService); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// BenchmarkResolveTransient and BenchmarkGetTyped compare the reflective and the typed path
+// for constructing a new instance on every call.
+func BenchmarkResolveTransient(b *testing.B) {
+	container := New()
+	if err := container.BindTransient(func() (Database, error) {
+		return &mockDatabase{}, nil
+	}); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var db Database
+		if err := container.Resolve(&db); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetTyped(b *testing.B) {
+	container := New()
+	if err := ProvideTyped(container, func() (Database, error) {
+		return &mockDatabase{}, nil
+	}); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Get[Database](container); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkResolveTransientDependencies measures argument resolution for a transient binding with three dependencies.
+func BenchmarkResolveTransientDependencies(b *testing.B) {
+	container := newBenchmarkContainer(b)
+	if err := container.Bind(func() Logger {
+		return &loggerImpl{}
+	}); err != nil {
+		b.Fatal(err)
+	}
+	if err := container.BindTransient(func(db Database, userService UserService, logger Logger) OrderService {
+		return &orderServiceImpl{db: db, userService: userService, logger: logger}
+	}); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var orderService OrderService
+		if err := container.Resolve(&orderService); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
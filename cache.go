@@ -0,0 +1,155 @@
+package di
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// WithContextKey caches one singleton instance per distinct value of ctx.Value(key), where ctx is the context
+// passed to ResolveContext, e.g. one instance per tenant. Resolutions without the value share one instance.
+// It can be combined with WithKeyFunc, in which case instances are cached per value and computed key.
+func WithContextKey(key interface{}) BindOption {
+	return func(config *bindConfig) {
+		config.ctxKey = key
+	}
+}
+
+// WithMaxCacheSize limits the number of instances a binding registered with WithKeyFunc or WithContextKey keeps cached.
+// Once the limit is exceeded, the least recently used instance is evicted and closed if it implements io.Closer.
+// A size of zero or less means no limit, which is the default.
+func WithMaxCacheSize(size int) BindOption {
+	return func(config *bindConfig) {
+		config.cacheSize = size
+	}
+}
+
+// perKey reports whether singleton instances are cached per key instead of once.
+func (b *binding) perKey() bool {
+	return b.keyFunc != nil || b.ctxKey != nil
+}
+
+// keyedCache holds the instances of a keyed binding in least recently used order.
+type keyedCache struct {
+	maxSize int                      // maximum number of entries, or 0 for no limit
+	entries map[string]*list.Element // entries by key
+	order   *list.List               // entries, most recently used first
+}
+
+type cacheEntry struct {
+	key      string
+	instance any
+}
+
+// get returns the instance cached under key and marks it as most recently used.
+func (k *keyedCache) get(key string) (any, bool) {
+	element, exists := k.entries[key]
+	if !exists {
+		return nil, false
+	}
+	k.order.MoveToFront(element)
+	return element.Value.(*cacheEntry).instance, true
+}
+
+// add caches the instance under key and returns the instances evicted to stay within the size limit.
+func (k *keyedCache) add(key string, instance any) []any {
+	if k.entries == nil {
+		k.entries = make(map[string]*list.Element)
+		k.order = list.New()
+	}
+	k.entries[key] = k.order.PushFront(&cacheEntry{key: key, instance: instance})
+
+	var evicted []any
+	for k.maxSize > 0 && k.order.Len() > k.maxSize {
+		entry := k.order.Remove(k.order.Back()).(*cacheEntry)
+		delete(k.entries, entry.key)
+		evicted = append(evicted, entry.instance)
+	}
+	return evicted
+}
+
+// dispose closes the instance if it implements io.Closer, logging a failure.
+func (c *Container) dispose(instance any) {
+	closer, ok := instance.(io.Closer)
+	if !ok {
+		return
+	}
+	if err := closer.Close(); err != nil {
+		c.log(fmt.Sprintf("di: closing %T failed: %v", instance, err))
+	}
+}
+
+// ClearAndClose is like Clear, but first closes the cached instances of every binding and keyed factory
+// that implement io.Closer, each exactly once. It returns the joined errors of the Close calls.
+func (c *Container) ClearAndClose() error {
+	c.lock.Lock()
+	bindings := c.bindings
+	multitons := c.multitons
+	c.bindings = make(map[reflect.Type]map[string]*binding)
+	c.decorators = make(map[reflect.Type][]*decorator)
+	c.callbacks = make(map[string][]reflect.Value)
+	c.multitons = nil
+	c.stoppers, c.services, c.running = nil, nil, 0
+	c.lock.Unlock()
+
+	// Instances shared with the container a transaction stages are closed there on commit
+	var instances []any
+	for _, named := range bindings {
+		for _, head := range named {
+			for b := head; b != nil; b = b.next {
+				if c.isInherited(b) {
+					c.lock.Lock()
+					c.invalidated = append(c.invalidated, b)
+					c.lock.Unlock()
+					continue
+				}
+				instances = append(instances, b.reset()...)
+			}
+		}
+	}
+	for _, m := range multitons {
+		if c.staging && c.inherited[m] {
+			c.lock.Lock()
+			c.cleared = append(c.cleared, m.(keyedInstances))
+			c.lock.Unlock()
+			continue
+		}
+		instances = append(instances, m.(keyedInstances).clear()...)
+	}
+
+	closed := make(map[any]bool)
+	var errs []error
+	for _, instance := range instances {
+		closer, ok := instance.(io.Closer)
+		if !ok {
+			continue
+		}
+		// Instances shared by several bindings are closed once
+		if reflect.TypeOf(closer).Comparable() {
+			if closed[closer] {
+				continue
+			}
+			closed[closer] = true
+		}
+		if err := closer.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("closing %T: %w", instance, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// clear removes every entry and returns the cached instances.
+func (k *keyedCache) clear() []any {
+	if k.order == nil {
+		return nil
+	}
+
+	var instances []any
+	for element := k.order.Front(); element != nil; element = element.Next() {
+		instances = append(instances, element.Value.(*cacheEntry).instance)
+	}
+	k.entries, k.order = nil, nil
+	return instances
+}
@@ -0,0 +1,140 @@
+package di
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type closableConnection struct {
+	dsn    string
+	closed bool
+}
+
+func (c *closableConnection) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestContainer_WithMaxCacheSize(t *testing.T) {
+	newContainer := func(t *testing.T, size int) (*Container, *int) {
+		container := New()
+		constructed := 0
+
+		err := container.Bind(func(ctx context.Context) *closableConnection {
+			constructed++
+			return &closableConnection{dsn: ctx.Value(ctxKey{}).(string)}
+		}, WithKeyFunc(func(args ...interface{}) string {
+			return args[0].(context.Context).Value(ctxKey{}).(string)
+		}), WithMaxCacheSize(size))
+		require.NoError(t, err)
+
+		return container, &constructed
+	}
+
+	resolve := func(t *testing.T, container *Container, database string) *closableConnection {
+		var conn *closableConnection
+		ctx := context.WithValue(context.Background(), ctxKey{}, database)
+		require.NoError(t, container.ResolveContext(ctx, &conn))
+		return conn
+	}
+
+	t.Run("least recently used entry is evicted and closed", func(t *testing.T) {
+		container, constructed := newContainer(t, 2)
+
+		orders := resolve(t, container, "orders")
+		users := resolve(t, container, "users")
+		assert.Same(t, orders, resolve(t, container, "orders"))
+
+		billing := resolve(t, container, "billing")
+
+		assert.True(t, users.closed)
+		assert.False(t, orders.closed)
+		assert.False(t, billing.closed)
+		assert.Same(t, orders, resolve(t, container, "orders"))
+		assert.Same(t, billing, resolve(t, container, "billing"))
+		assert.Equal(t, 3, *constructed)
+	})
+
+	t.Run("evicted key is constructed again", func(t *testing.T) {
+		container, constructed := newContainer(t, 1)
+
+		orders := resolve(t, container, "orders")
+		resolve(t, container, "users")
+		again := resolve(t, container, "orders")
+
+		assert.True(t, orders.closed)
+		assert.NotSame(t, orders, again)
+		assert.Equal(t, 3, *constructed)
+	})
+
+	t.Run("no limit by default", func(t *testing.T) {
+		container, constructed := newContainer(t, 0)
+
+		for _, database := range []string{"orders", "users", "billing"} {
+			resolve(t, container, database)
+		}
+		orders := resolve(t, container, "orders")
+
+		assert.False(t, orders.closed)
+		assert.Equal(t, 3, *constructed)
+	})
+}
+
+type tenantKey struct{}
+
+func TestContainer_WithContextKey(t *testing.T) {
+	container := New()
+	err := container.Bind(func() Database {
+		return &mockDatabase{}
+	}, WithContextKey(tenantKey{}))
+	require.NoError(t, err)
+
+	resolve := func(tenant string) Database {
+		var db Database
+		ctx := context.WithValue(context.Background(), tenantKey{}, tenant)
+		require.NoError(t, container.ResolveContext(ctx, &db))
+		return db
+	}
+
+	acme := resolve("acme")
+	globex := resolve("globex")
+
+	assert.NotSame(t, acme, globex)
+	assert.Same(t, acme, resolve("acme"))
+	assert.Same(t, globex, resolve("globex"))
+}
+
+type countingCloser struct {
+	closes int
+}
+
+func (c *countingCloser) Close() error {
+	c.closes++
+	return nil
+}
+
+func TestContainer_ClearAndClose(t *testing.T) {
+	container := New()
+	closer := &countingCloser{}
+	unused := &countingCloser{}
+	require.NoError(t, container.Bind(func() *countingCloser { return closer }))
+	require.NoError(t, container.BindNamed("unused", func() *countingCloser { return unused }))
+
+	var resolved *countingCloser
+	require.NoError(t, container.Resolve(&resolved))
+
+	require.NoError(t, container.ClearAndClose())
+	assert.Equal(t, 1, closer.closes)
+	assert.Equal(t, 0, unused.closes)
+	assert.ErrorIs(t, container.Resolve(&resolved), ErrBindingNotFound)
+
+	// Clear leaves instances open
+	container = New()
+	require.NoError(t, container.Bind(func() *countingCloser { return closer }))
+	require.NoError(t, container.Resolve(&resolved))
+	container.Clear()
+	assert.Equal(t, 1, closer.closes)
+}
@@ -0,0 +1,92 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// BindCallback registers a callback under the event name. The callback must be a function returning
+// nothing or an error; its parameters are filled by Trigger, from the trigger arguments or the container.
+// Several callbacks can be registered under the same name.
+func (c *Container) BindCallback(name string, callback interface{}) error {
+	callbackType := reflect.TypeOf(callback)
+	if callbackType == nil || callbackType.Kind() != reflect.Func {
+		return fmt.Errorf("container: the callback %w", ErrNotAFunction)
+	}
+	if callbackType.NumOut() > 1 || (callbackType.NumOut() == 1 && callbackType.Out(0) != errorType) {
+		return errors.New("callback must return nothing or an error")
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// Cap the current slice so append copies it; triggers in flight may still be iterating it
+	callbacks := c.callbacks[name]
+	c.callbacks[name] = append(callbacks[:len(callbacks):len(callbacks)], reflect.ValueOf(callback))
+	return nil
+}
+
+// Trigger calls every callback registered under the event name, in registration order. Each parameter
+// receives the first unused argument assignable to it, or is resolved from the container otherwise.
+// Every callback is called even if an earlier one fails; their errors are joined.
+func (c *Container) Trigger(name string, args ...interface{}) error {
+	c.lock.RLock()
+	callbacks := c.callbacks[name]
+	c.lock.RUnlock()
+
+	var errs []error
+	for _, callback := range callbacks {
+		arguments, err := c.callbackArguments(callback.Type(), args)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("callback %s for '%s': %w", callback.Type(), name, err))
+			continue
+		}
+
+		results := callback.Call(arguments)
+		if len(results) == 1 && !results[0].IsNil() {
+			errs = append(errs, results[0].Interface().(error))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// callbackArguments fills the callback's parameters from the trigger arguments and the container.
+func (c *Container) callbackArguments(callbackType reflect.Type, args []interface{}) ([]reflect.Value, error) {
+	used := make([]bool, len(args))
+	arguments := make([]reflect.Value, callbackType.NumIn())
+
+	r := c.begin(context.Background())
+	for i := range arguments {
+		argType := callbackType.In(i)
+		for j, arg := range args {
+			if !used[j] && (arg == nil && canBeNil(argType) || arg != nil && reflect.TypeOf(arg).AssignableTo(argType)) {
+				used[j] = true
+				arguments[i] = valueOf(arg, argType)
+				break
+			}
+		}
+		if arguments[i].IsValid() {
+			continue
+		}
+
+		argument, err := c.resolveArgument(r, argType)
+		if err != nil {
+			return nil, err
+		}
+		arguments[i] = argument
+	}
+
+	return arguments, nil
+}
+
+// canBeNil reports whether nil is a valid value of the type.
+func canBeNil(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+		return true
+	}
+	return false
+}
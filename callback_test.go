@@ -0,0 +1,78 @@
+package di
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type orderPlaced struct {
+	id int
+}
+
+func TestContainer_Callbacks(t *testing.T) {
+	t.Run("every callback receives the arguments", func(t *testing.T) {
+		container := New()
+		var received []string
+
+		err := container.BindCallback("order.placed", func(event orderPlaced, source string) {
+			received = append(received, fmt.Sprintf("audit %d from %s", event.id, source))
+		})
+		require.NoError(t, err)
+		err = container.BindCallback("order.placed", func(event orderPlaced) error {
+			received = append(received, fmt.Sprintf("email %d", event.id))
+			return nil
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, container.Trigger("order.placed", orderPlaced{id: 42}, "checkout"))
+		assert.Equal(t, []string{"audit 42 from checkout", "email 42"}, received)
+	})
+
+	t.Run("remaining parameters are resolved from the container", func(t *testing.T) {
+		container := New()
+		db := &mockDatabase{}
+		require.NoError(t, container.Bind(func() Database { return db }))
+
+		var injected Database
+		err := container.BindCallback("order.placed", func(event orderPlaced, db Database) {
+			injected = db
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, container.Trigger("order.placed", orderPlaced{id: 1}))
+		assert.Same(t, db, injected)
+	})
+
+	t.Run("errors are joined after calling every callback", func(t *testing.T) {
+		container := New()
+		called := 0
+		errFirst, errSecond := errors.New("first"), errors.New("second")
+
+		require.NoError(t, container.BindCallback("tick", func() error { called++; return errFirst }))
+		require.NoError(t, container.BindCallback("tick", func(db Database) { called++ }))
+		require.NoError(t, container.BindCallback("tick", func() error { called++; return errSecond }))
+
+		err := container.Trigger("tick")
+		assert.ErrorIs(t, err, errFirst)
+		assert.ErrorIs(t, err, errSecond)
+		assert.ErrorIs(t, err, ErrBindingNotFound)
+		assert.Equal(t, 2, called)
+	})
+
+	t.Run("unknown event", func(t *testing.T) {
+		container := New()
+
+		assert.NoError(t, container.Trigger("missing"))
+	})
+
+	t.Run("invalid callbacks", func(t *testing.T) {
+		container := New()
+
+		assert.ErrorIs(t, container.BindCallback("tick", "not a function"), ErrNotAFunction)
+		assert.EqualError(t, container.BindCallback("tick", func() int { return 0 }), "callback must return nothing or an error")
+	})
+}
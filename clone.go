@@ -0,0 +1,106 @@
+package di
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// WithCloneOnResolve constructs the instance once, as a prototype, and hands out a deep copy of it on
+// every resolution, which is cheaper than running an expensive factory each time while still giving every
+// consumer an independent instance. Hooks and decorators apply to the prototype, which is never handed out.
+//
+// The copy is deep: pointers, slices, maps, arrays, structs and the values held by interfaces are copied
+// recursively, including unexported struct fields, so no mutable memory is shared with the prototype.
+// Pointers that refer to the same value in the prototype refer to the same copy in the clone, which also
+// keeps cyclic structures intact. Channels, functions and unsafe pointers are not copied; the clone
+// shares them with the prototype.
+func WithCloneOnResolve() BindOption {
+	return func(config *bindConfig) {
+		config.clone = true
+		config.singleton = true
+	}
+}
+
+// cloneKey identifies an already copied pointer target, by address and type since a struct and its first
+// field share their address.
+type cloneKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// deepCopy returns a deep copy of the instance, as described by WithCloneOnResolve.
+func deepCopy(instance any) any {
+	if instance == nil {
+		return nil
+	}
+	return copyValue(reflect.ValueOf(instance), make(map[cloneKey]reflect.Value)).Interface()
+}
+
+// copyValue returns a deep copy of the value. The value must not be obtained through unexported struct fields.
+func copyValue(src reflect.Value, copied map[cloneKey]reflect.Value) reflect.Value {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return reflect.Zero(src.Type())
+		}
+		key := cloneKey{ptr: src.Pointer(), typ: src.Type()}
+		if dst, exists := copied[key]; exists {
+			return dst
+		}
+		dst := reflect.New(src.Type().Elem())
+		copied[key] = dst
+		dst.Elem().Set(copyValue(src.Elem(), copied))
+		return dst
+
+	case reflect.Interface:
+		if src.IsNil() {
+			return reflect.Zero(src.Type())
+		}
+		dst := reflect.New(src.Type()).Elem()
+		dst.Set(copyValue(src.Elem(), copied))
+		return dst
+
+	case reflect.Struct:
+		dst := reflect.New(src.Type()).Elem()
+		dst.Set(src)
+		for i := 0; i < dst.NumField(); i++ {
+			// Fields are copied in place, through a settable view of unexported ones
+			field := dst.Field(i)
+			if !field.CanSet() {
+				field = reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+			}
+			field.Set(copyValue(field, copied))
+		}
+		return dst
+
+	case reflect.Array:
+		dst := reflect.New(src.Type()).Elem()
+		for i := 0; i < src.Len(); i++ {
+			dst.Index(i).Set(copyValue(src.Index(i), copied))
+		}
+		return dst
+
+	case reflect.Slice:
+		if src.IsNil() {
+			return reflect.Zero(src.Type())
+		}
+		dst := reflect.MakeSlice(src.Type(), src.Len(), src.Cap())
+		for i := 0; i < src.Len(); i++ {
+			dst.Index(i).Set(copyValue(src.Index(i), copied))
+		}
+		return dst
+
+	case reflect.Map:
+		if src.IsNil() {
+			return reflect.Zero(src.Type())
+		}
+		dst := reflect.MakeMapWithSize(src.Type(), src.Len())
+		for iter := src.MapRange(); iter.Next(); {
+			dst.SetMapIndex(copyValue(iter.Key(), copied), copyValue(iter.Value(), copied))
+		}
+		return dst
+
+	default:
+		return src
+	}
+}
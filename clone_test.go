@@ -0,0 +1,93 @@
+package di
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type settings struct {
+	Name     string
+	Limits   []int
+	Headers  map[string]string
+	Database *mockDatabase
+	Parent   *settings
+	secret   *string
+	notify   chan string
+}
+
+func TestWithCloneOnResolve(t *testing.T) {
+	t.Run("resolutions receive independent copies", func(t *testing.T) {
+		container := New()
+		constructed := 0
+		secret := "token"
+		err := container.Bind(func() *settings {
+			constructed++
+			s := &settings{
+				Name:     "default",
+				Limits:   []int{1, 2},
+				Headers:  map[string]string{"accept": "json"},
+				Database: &mockDatabase{},
+				secret:   &secret,
+				notify:   make(chan string),
+			}
+			s.Parent = s
+			return s
+		}, WithCloneOnResolve())
+		require.NoError(t, err)
+
+		var first, second *settings
+		require.NoError(t, container.Resolve(&first))
+		require.NoError(t, container.Resolve(&second))
+		assert.Equal(t, 1, constructed)
+		assert.NotSame(t, first, second)
+		assert.Equal(t, first.Limits, second.Limits)
+
+		first.Name = "changed"
+		first.Limits[0] = 10
+		first.Headers["accept"] = "xml"
+		first.Database.connected = true
+		*first.secret = "leaked"
+
+		assert.Equal(t, "default", second.Name)
+		assert.Equal(t, []int{1, 2}, second.Limits)
+		assert.Equal(t, "json", second.Headers["accept"])
+		assert.False(t, second.Database.connected)
+		assert.Equal(t, "token", *second.secret)
+		assert.Equal(t, "token", secret)
+
+		assert.Same(t, second, second.Parent)
+		assert.Equal(t, first.notify, second.notify)
+	})
+
+	t.Run("interface bindings are copied", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func() Database {
+			return &mockDatabase{}
+		}, WithCloneOnResolve())
+		require.NoError(t, err)
+
+		var first, second Database
+		require.NoError(t, container.Resolve(&first))
+		require.NoError(t, first.Connect())
+		require.NoError(t, container.Resolve(&second))
+		assert.NotSame(t, first, second)
+		assert.False(t, second.(*mockDatabase).connected)
+	})
+
+	t.Run("injected dependencies are copies", func(t *testing.T) {
+		container := New()
+		require.NoError(t, container.Bind(func() Database {
+			return &mockDatabase{}
+		}, WithCloneOnResolve()))
+		require.NoError(t, container.BindTransient(func(db Database) UserService {
+			return &userServiceImpl{db: db}
+		}))
+
+		var first, second UserService
+		require.NoError(t, container.Resolve(&first))
+		require.NoError(t, container.Resolve(&second))
+		assert.NotSame(t, first.(*userServiceImpl).db, second.(*userServiceImpl).db)
+	})
+}
@@ -1,10 +1,15 @@
 package di
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // BindOption represents a configuration option for binding
@@ -12,9 +17,38 @@ type BindOption func(*bindConfig)
 
 // bindConfig holds the configuration for a binding
 type bindConfig struct {
-	name      string
-	singleton bool
-	lazy      bool
+	name        string
+	singleton   bool
+	lazy        bool
+	fallback    interface{}
+	labels      map[string]string
+	group       string
+	tags        []string
+	autoAddr    bool
+	keyFunc     func(args ...interface{}) string
+	precond     func() error
+	validate    func(instance interface{}) error
+	params      []string
+	cacheSize   int
+	condition   func() bool
+	profile     string
+	reactive    bool
+	onStart     func(instance any) error
+	onStop      func(instance any) error
+	timeout     time.Duration
+	ctxKey      interface{}
+	slots       int
+	background  bool
+	scoped      bool
+	bestEffort  bool
+	nameAware   bool
+	spread      bool
+	spreadNamer func(index int, element interface{}) string
+	typed       func() (any, error)
+	weak        time.Duration
+	clone       bool
+	priority    int
+	ranked      bool
 }
 
 // WithName sets a name for the binding, allowing multiple implementations of the same interface
@@ -45,277 +79,1627 @@ func WithLazy() BindOption {
 	}
 }
 
-// WithEager makes the binding eager (instance created immediately during binding)
+// WithEager makes the binding eager (instance created immediately during binding), surfacing initialization
+// errors early. A singleton reset with ResetSingleton is rebuilt lazily on the next resolution.
 func WithEager() BindOption {
 	return func(config *bindConfig) {
 		config.lazy = false
 	}
 }
 
+// WithFailSafe registers a fallback factory that is used when the primary factory panics or returns an error.
+// The failure is reported to the container's logger instead of being propagated.
+func WithFailSafe(fallbackFactory interface{}) BindOption {
+	return func(config *bindConfig) {
+		config.fallback = fallbackFactory
+	}
+}
+
+// WithGroup adds the binding to a named group. Group members without an explicit name are
+// registered under a generated name, so several of them can be bound for the same type.
+// A constructor parameter of type []T receives every binding of T in registration order.
+func WithGroup(group string) BindOption {
+	return func(config *bindConfig) {
+		config.group = group
+	}
+}
+
+// WithTags attaches tags to the binding. Unlike names, a binding can carry several tags
+// and many bindings can share a tag; see ResolveByTag.
+func WithTags(tags ...string) BindOption {
+	return func(config *bindConfig) {
+		config.tags = append(config.tags, tags...)
+	}
+}
+
+// WithKeyFunc caches singleton instances per key instead of once per binding. The key is computed
+// from the resolved constructor arguments, so resolutions whose arguments map to the same key share
+// an instance while a different key yields a new one.
+func WithKeyFunc(keyFunc func(args ...interface{}) string) BindOption {
+	return func(config *bindConfig) {
+		config.keyFunc = keyFunc
+	}
+}
+
+// WithPrecondition runs the check before the binding constructs an instance.
+// A non-nil error aborts the resolution and is returned wrapped with the binding's type.
+func WithPrecondition(check func() error) BindOption {
+	return func(config *bindConfig) {
+		config.precond = check
+	}
+}
+
+// WithParamNames resolves the factory's parameters from the bindings with the given names, by position,
+// so a factory can receive several bindings of the same type. An empty name keeps the default resolution.
+func WithParamNames(names ...string) BindOption {
+	return func(config *bindConfig) {
+		config.params = names
+	}
+}
+
+// WithValidate checks every instance the binding constructs, after decorators have been applied.
+// A non-nil error fails the resolution, wrapped with the binding's type, and the instance is not cached.
+func WithValidate(validate func(instance interface{}) error) BindOption {
+	return func(config *bindConfig) {
+		config.validate = validate
+	}
+}
+
+// WithAutoAddr stores the address of the value returned by the factory, so that a binding of a value type T
+// can also satisfy interfaces that are implemented on *T. Resolving T itself returns a copy of the stored value.
+func WithAutoAddr() BindOption {
+	return func(config *bindConfig) {
+		config.autoAddr = true
+	}
+}
+
+// WithCondition makes the binding take effect only while the condition holds, e.g. for feature flags.
+// Unlike other bindings, a conditional binding does not replace an existing binding with the same type and name;
+// resolution picks the earliest registered candidate whose condition currently holds, and an unconditional
+// candidate always qualifies.
+func WithCondition(condition func() bool) BindOption {
+	return func(config *bindConfig) {
+		config.condition = condition
+	}
+}
+
+// WithLabel attaches a key/value label to the binding, which can be matched with ResolveWhere.
+func WithLabel(key, value string) BindOption {
+	return func(config *bindConfig) {
+		if config.labels == nil {
+			config.labels = make(map[string]string)
+		}
+		config.labels[key] = value
+	}
+}
+
+// resolution carries the state of a single top-level resolution through nested factory calls.
+type resolution struct {
+	ctx          context.Context
+	overrides    map[reflect.Type]reflect.Value // per-call values injected instead of bindings
+	usedOverride bool                           // whether the current construction consumed an override
+	resolving    []*binding                     // bindings currently being resolved, outermost first
+	timingParent int                            // ID of the timing entry currently being constructed, or -1
+	scope        *Scope                         // scope caching scoped bindings, nil outside a scope
+	injected     []*construction                // constructions injected into the factories being run, innermost last
+}
+
+func newResolution(ctx context.Context) *resolution {
+	return &resolution{ctx: ctx, timingParent: -1}
+}
+
+// path describes the chain of types being resolved, ending with next.
+func (r *resolution) path(next reflect.Type) string {
+	types := make([]string, 0, len(r.resolving)+1)
+	for _, b := range r.resolving {
+		types = append(types, b.typ.String())
+	}
+	return strings.Join(append(types, next.String()), " -> ")
+}
+
+var (
+	contextType   = reflect.TypeOf((*context.Context)(nil)).Elem()
+	containerType = reflect.TypeOf((*Container)(nil))
+)
+
 type binding struct {
-	resolver  any        // factory function or value
-	concrete  any        // concrete type
-	singleton bool       // whether the binding is a singleton
-	mutex     sync.Mutex // protects concrete for singleton instances
+	typ          reflect.Type                     // type the binding is registered under
+	name         string                           // name the binding is registered under
+	labels       map[string]string                // user-defined metadata
+	group        string                           // group the binding belongs to, if any
+	tags         []string                         // tags attached with WithTags
+	seq          uint64                           // registration sequence number
+	resolver     any                              // factory function or value
+	in           []reflect.Type                   // parameter types of the resolver, computed once at bind time
+	params       []string                         // binding names of the resolver's parameters, by position
+	out          int                              // index of the resolver result the binding produces
+	shared       *sharedCall                      // shares invocations with the other results of the resolver, if any
+	fallback     any                              // fallback factory used when the resolver fails
+	concrete     atomic.Pointer[any]              // cached singleton instance
+	keyFunc      func(args ...interface{}) string // computes the cache key from resolved arguments
+	ctxKey       interface{}                      // context key whose value instances are cached by, if any
+	keyed        keyedCache                       // instances cached per key, protected by mutex
+	precond      func() error                     // checked before constructing an instance
+	validate     func(instance interface{}) error // checks constructed instances
+	condition    func() bool                      // whether the binding currently takes effect, nil for always
+	profile      string                           // profile the binding belongs to, empty for every profile
+	next         *binding                         // previously registered candidate for the same type and name
+	reactive     bool                             // whether the instance is reset along with its dependencies
+	onStart      func(instance any) error         // called after a singleton instance is constructed
+	onStop       func(instance any) error         // called for started singleton instances by Close
+	timeout      time.Duration                    // how long the resolver may run, zero for no limit
+	slots        chan struct{}                    // limits concurrent constructions, nil for no limit
+	ready        chan struct{}                    // closed once the background construction is done, nil without one
+	readyErr     error                            // error of the background construction, set before ready is closed
+	dependents   sync.Map                         // reactive bindings built from this binding's instance, as *binding keys
+	singleton    bool                             // whether the binding is a singleton
+	scoped       bool                             // whether instances are cached per Scope
+	bestEffort   bool                             // whether failures during startup are logged instead of aborting it
+	weak         time.Duration                    // how long the cached instance is kept without being resolved, zero for ever
+	clone        bool                             // whether resolutions receive deep copies of the cached instance
+	priority     int                              // rank among candidates with the same type and name, see WithPriority
+	ranked       bool                             // whether the priority was set with WithPriority
+	typed        func() (any, error)              // calls the factory without reflection, for bindings registered with ProvideTyped
+	config       *bindConfig                      // configuration the binding was registered with, see Replace
+	template     *bindConfig                      // configuration of the bindings created per name, for name-aware bindings
+	children     sync.Map                         // bindings created per name from a name-aware binding, by name
+	parent       *binding                         // name-aware binding this binding was created from, if any
+	origin       *binding                         // binding a merged copy shares its instances with, see Merge
+	eager        bool                             // whether the binding is instantiated by Start
+	autoAddr     bool                             // whether instances are stored as pointers to the returned value
+	fallbackUsed atomic.Bool                      // whether the fallback factory produced an instance
+	constructed  atomic.Bool                      // whether the binding ever produced an instance, see UnusedBindings
+	lastUsed     atomic.Int64                     // when the cached instance was last resolved, for weak bindings, see sinceStart
+	mutex        sync.Mutex                       // serializes construction of singleton instances
+	builders     sync.Map                         // goroutines constructing an instance, by goroutine ID, see lock and nest
+	nesting      atomic.Int32                     // constructions in progress that do not take the mutex, see nest
+}
+
+// lifetime overrides the lifetime of a binding for a single resolution.
+type lifetime int
+
+const (
+	lifetimeDefault   lifetime = iota // use the lifetime the binding was registered with
+	lifetimeTransient                 // always construct a new instance, leaving the cache untouched
+	lifetimeSingleton                 // return the cached instance, constructing and caching it on first use
+)
+
+func (b *binding) resolve(c *Container, r *resolution) (any, error) {
+	return b.resolveLifetime(c, r, lifetimeDefault)
+}
+
+func (b *binding) resolveLifetime(c *Container, r *resolution, lt lifetime) (any, error) {
+	instance, err := b.obtain(c, r, lt)
+	if err != nil || !b.autoAddr {
+		return instance, err
+	}
+	return reflect.ValueOf(instance).Elem().Interface(), nil
+}
+
+// obtain returns a cached or newly constructed instance in its stored form, which is a pointer for auto-addressed bindings.
+func (b *binding) obtain(c *Container, r *resolution, lt lifetime) (instance any, err error) {
+	if b.origin != nil {
+		return b.origin.obtain(c, r, lt)
+	}
+
+	// Staging containers construct singletons into their own copies of the bindings they share
+	if c.staging && lt != lifetimeTransient && (b.singleton || lt == lifetimeSingleton) && b.concrete.Load() == nil {
+		if owned := c.own(b); owned == nil {
+			lt = lifetimeTransient
+		} else if owned != b {
+			return owned.obtain(c, r, lt)
+		}
+	}
+
+	if observer := c.observer.Load(); observer != nil {
+		(*observer).OnResolveStart(b.typ, b.name)
+		start := time.Now()
+		defer func() {
+			(*observer).OnResolveEnd(b.typ, b.name, time.Since(start), err)
+		}()
+	}
+
+	if b.clone {
+		defer func() {
+			if err == nil {
+				instance = deepCopy(instance)
+			}
+		}()
+	}
+
+	singleton := b.singleton
+	switch lt {
+	case lifetimeTransient:
+		singleton = false
+	case lifetimeSingleton:
+		singleton = true
+	}
+
+	// Reactive parents are reset along with their dependencies, so remember who depends on b
+	if len(r.resolving) > 0 {
+		if parent := r.resolving[len(r.resolving)-1]; parent.reactive {
+			b.dependents.Store(parent, true)
+		}
+	}
+
+	// Fast path: cached singletons are read without taking the lock
+	if singleton && !b.perKey() {
+		if cached := b.concrete.Load(); cached != nil {
+			b.touch()
+			return *cached, nil
+		}
+	}
+
+	// Detect cycles before taking the singleton lock, which would otherwise deadlock
+	for _, resolving := range r.resolving {
+		if resolving == b {
+			return nil, b.circular()
+		}
+	}
+
+	r.resolving = append(r.resolving, b)
+	defer r.leave()
+
+	// Scoped bindings are cached by the scope of the resolution
+	if b.scoped && lt == lifetimeDefault {
+		return r.scope.obtain(c, r, b)
+	}
+
+	// Keyed singletons are cached per key computed from their arguments or context
+	if singleton && b.perKey() {
+		return b.obtainKeyed(c, r)
+	}
+
+	// For singleton bindings, use mutex so the factory runs exactly once
+	if singleton {
+		if err := b.lock(); err != nil {
+			return nil, err
+		}
+		defer b.mutex.Unlock()
+
+		// Check again, another goroutine may have cached an instance while we waited
+		if cached := b.concrete.Load(); cached != nil {
+			b.touch()
+			return *cached, nil
+		}
+		defer b.build()()
+
+		// Create the instance, tracking whether it depends on a per-call override
+		parentUsedOverride := r.usedOverride
+		r.usedOverride = false
+		val, err := b.construct(c, r)
+		usedOverride := r.usedOverride
+		r.usedOverride = parentUsedOverride || usedOverride
+		if err != nil {
+			return nil, err
+		}
+
+		// Instances built from per-call overrides belong to that call only
+		if usedOverride {
+			return val, nil
+		}
+
+		if err := c.startInstance(b, val); err != nil {
+			return nil, err
+		}
+
+		// Cache it for future use
+		b.cache(val)
+		return val, nil
+	}
+
+	// For transient bindings, just create a new instance each time
+	end, err := b.nest()
+	if err != nil {
+		return nil, err
+	}
+	defer end()
+	return b.construct(c, r)
+}
+
+func (b *binding) hasTag(tag string) bool {
+	for _, t := range b.tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// obtainKeyed returns the instance cached under the key computed from the context value
+// and the resolved constructor arguments. Each instance runs the lifecycle hooks of a singleton.
+func (b *binding) obtainKeyed(c *Container, r *resolution) (any, error) {
+	var key string
+	if b.ctxKey != nil {
+		value := r.ctx.Value(b.ctxKey)
+		key = fmt.Sprintf("%T:%v|", value, value)
+	}
+
+	var arguments []reflect.Value
+	if b.keyFunc != nil {
+		var err error
+		if arguments, err = c.resolveArguments(r, b.in, b.params); err != nil {
+			return nil, err
+		}
+
+		values := make([]interface{}, len(arguments))
+		for i, argument := range arguments {
+			values[i] = argument.Interface()
+		}
+		key += b.keyFunc(values...)
+	}
+
+	// Evicted instances are discarded after the lock is released
+	var evicted []any
+	defer func() {
+		for _, instance := range evicted {
+			c.discard(instance)
+		}
+	}()
+
+	if err := b.lock(); err != nil {
+		return nil, err
+	}
+	defer b.mutex.Unlock()
+
+	if instance, exists := b.keyed.get(key); exists {
+		return instance, nil
+	}
+	defer b.build()()
+
+	val, err := b.constructWith(c, r, arguments)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.startInstance(b, val); err != nil {
+		return nil, err
+	}
+
+	evicted = b.keyed.add(key, val)
+	return val, nil
+}
+
+// construct creates a new instance and passes it through the decorators registered for the binding's type.
+func (b *binding) construct(c *Container, r *resolution) (any, error) {
+	return b.constructWith(c, r, nil)
+}
+
+// constructWith is like construct, but calls the resolver with already resolved arguments if they are not nil.
+func (b *binding) constructWith(c *Container, r *resolution, arguments []reflect.Value) (any, error) {
+	if err := r.ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if b.precond != nil {
+		if err := b.precond(); err != nil {
+			return nil, fmt.Errorf("precondition failed for %s: %w", b.typ, err)
+		}
+	}
+
+	if done := c.startTiming(r, b); done != nil {
+		defer done()
+	}
+
+	val, err := b.limit(r, func() (any, error) {
+		return b.instantiate(c, r, arguments)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	val, err = c.decorate(r, b.typ, val)
+	if err != nil {
+		return nil, err
+	}
+	b.markConstructed()
+
+	if b.validate != nil {
+		if err := b.validate(val); err != nil {
+			return nil, fmt.Errorf("validation failed for %s: %w", b.typ, err)
+		}
+	}
+
+	if !b.autoAddr {
+		return val, nil
+	}
+
+	ptr := reflect.New(b.typ)
+	ptr.Elem().Set(valueOf(val, b.typ))
+	return ptr.Interface(), nil
+}
+
+// instantiate calls the resolver, switching to the fallback factory if one is configured and the resolver fails.
+func (b *binding) instantiate(c *Container, r *resolution, arguments []reflect.Value) (any, error) {
+	if b.fallback == nil {
+		return b.callResolver(c, r, arguments)
+	}
+
+	val, err := callRecover(func() (any, error) {
+		return b.callResolver(c, r, arguments)
+	})
+	if err == nil {
+		return val, nil
+	}
+
+	c.log(fmt.Sprintf("di: factory for %s failed, using fallback: %v", b.typ, err))
+	values, err := c.callResolver(r, b.fallback, nil)
+	if err != nil {
+		return nil, err
+	}
+	b.fallbackUsed.Store(true)
+	return values[b.out].Interface(), nil
+}
+
+// callResolver calls the binding's resolver, resolving its arguments unless they are provided,
+// and returns the result the binding produces.
+func (b *binding) callResolver(c *Container, r *resolution, arguments []reflect.Value) (any, error) {
+	if b.shared != nil && b.singleton && !b.perKey() {
+		return b.shared.call(c, r, b)
+	}
+
+	if arguments == nil {
+		var err error
+		if arguments, err = c.resolveArguments(r, b.in, b.params); err != nil {
+			return nil, err
+		}
+	}
+
+	values, err := b.call(arguments)
+	if err != nil {
+		return nil, err
+	}
+	return values[b.out].Interface(), nil
+}
+
+// sharedCall memoizes an invocation of a resolver with several results, so that singleton bindings
+// for the results are constructed from a single call. Each result is handed out once; a result that is
+// requested again, e.g. after ResetSingleton, triggers a new invocation.
+type sharedCall struct {
+	mutex    sync.Mutex
+	siblings []*binding  // bindings for each result, indexed by result
+	pending  map[int]any // results of the last invocation that were not consumed yet
+}
+
+func (s *sharedCall) call(c *Container, r *resolution, b *binding) (any, error) {
+	if instance, exists := s.take(b); exists {
+		return instance, nil
+	}
+
+	// Arguments are resolved without the lock, so that a dependency on a sibling is reported as a cycle
+	arguments, err := c.resolveArguments(r, b.in, b.params)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if instance, exists := s.pending[b.out]; exists {
+		delete(s.pending, b.out)
+		return instance, nil
+	}
+
+	values, err := b.call(arguments)
+	if err != nil {
+		return nil, err
+	}
+	for _, sibling := range s.siblings {
+		if sibling != b && sibling.concrete.Load() == nil {
+			s.pending[sibling.out] = values[sibling.out].Interface()
+		}
+	}
+	return values[b.out].Interface(), nil
+}
+
+// take removes and returns the pending result for the binding.
+func (s *sharedCall) take(b *binding) (any, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	instance, exists := s.pending[b.out]
+	delete(s.pending, b.out)
+	return instance, exists
+}
+
+// Container holds bindings and resolves instances from them. It is safe for concurrent use.
+// The lock guards the container's fields only; it is never held while factories or decorators run,
+// so they may resolve from or bind into the same container.
+type Container struct {
+	*registry
+	injected *construction // construction the container was injected into, nil for containers created by New
+}
+
+// registry holds the state of a container, shared with the containers injected into its factories.
+type registry struct {
+	bindings   map[reflect.Type]map[string]*binding
+	decorators map[reflect.Type][]*decorator
+	callbacks  map[string][]reflect.Value        // callbacks by event name, see BindCallback
+	factories  map[string]map[string]interface{} // factories by type name and binding name, see RegisterFactory
+	multitons  map[multitonKey]any               // keyed factories, see BindKeyed
+	logger     Logger
+	lock       sync.RWMutex
+	seq        uint64 // sequence number of the last registered binding
+	deferEager bool   // whether eager bindings are instantiated by Start instead of Bind
+
+	panicOnMissing bool                     // whether missing bindings panic instead of returning an error
+	onMissing      MissingHandler           // supplies instances for missing bindings, see OnMissing
+	stoppers       []stopper                // started singletons with a stop hook, in construction order
+	services       []any                    // constructed singletons implementing Startable or Stoppable, in construction order
+	running        int                      // number of services started by Start
+	observer       atomic.Pointer[Observer] // notified around resolutions, see SetObserver
+	profiles       map[string]bool          // profiles whose bindings take effect, see SetActiveProfiles
+
+	recordTimings bool
+	timings       []TimingEntry
+	timingsMutex  sync.Mutex // protects recordTimings and timings
+
+	implicitInterfaces bool // whether concrete bindings satisfy interfaces they implement, see SetImplicitInterfaces
+
+	staging     bool                  // whether the container stages a Transaction
+	settings    []func(r *registry)   // settings applied while staging, applied again on commit
+	inherited   map[any]bool          // bindings and keyed factories shared with the staged container, never written while staging
+	owned       map[*binding]*binding // copies of inherited bindings written while staging, by inherited binding
+	invalidated []*binding            // inherited bindings to invalidate in the staged container on commit
+	cleared     []keyedInstances      // inherited keyed factories to clear in the staged container on commit
+}
+
+func New() *Container {
+	return &Container{registry: &registry{
+		bindings:   make(map[reflect.Type]map[string]*binding),
+		decorators: make(map[reflect.Type][]*decorator),
+		callbacks:  make(map[string][]reflect.Value),
+		logger:     stdLogger{},
+	}}
+}
+
+// SetPanicOnMissing makes resolution panic with the full dependency path when a binding is missing,
+// instead of returning an error. It is meant for development environments that prefer loud failures.
+func (c *Container) SetPanicOnMissing(panicOnMissing bool) {
+	c.configure(func(r *registry) {
+		r.panicOnMissing = panicOnMissing
+	})
+}
+
+// missing returns err, or panics with it if the container is configured to panic on missing bindings.
+func (c *Container) missing(err error) error {
+	c.lock.RLock()
+	panicOnMissing := c.panicOnMissing
+	c.lock.RUnlock()
+
+	if panicOnMissing {
+		panic(err)
+	}
+	return err
+}
+
+// log writes a diagnostic message to the container's logger.
+func (c *Container) log(message string) {
+	c.lock.RLock()
+	logger := c.logger
+	c.lock.RUnlock()
+
+	logger.Log(message)
+}
+
+// SetLogger replaces the logger used for container diagnostics.
+func (c *Container) SetLogger(logger Logger) {
+	c.configure(func(r *registry) {
+		r.logger = logger
+	})
+}
+
+// Clear removes every binding, decorator, callback and keyed factory. The constructed singletons are forgotten:
+// Start, Stop and Close no longer reach them.
+func (c *Container) Clear() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.bindings = make(map[reflect.Type]map[string]*binding)
+	c.decorators = make(map[reflect.Type][]*decorator)
+	c.callbacks = make(map[string][]reflect.Value)
+	c.multitons = nil
+	c.stoppers, c.services, c.running = nil, nil, 0
+}
+
+// Unbind removes the default binding for the type the target points to.
+func (c *Container) Unbind(target interface{}) error {
+	return c.UnbindNamed(target, "")
+}
+
+// UnbindNamed removes the binding registered under name for the type the target points to.
+// Instances that were already resolved are left untouched.
+func (c *Container) UnbindNamed(target interface{}, name string) error {
+	targetType := reflect.TypeOf(target)
+	if targetType == nil || targetType.Kind() != reflect.Ptr {
+		return ErrNotAPointer
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, exists := c.bindings[targetType.Elem()][name]; !exists {
+		return fmt.Errorf("%w for type %s with name '%s'", ErrBindingNotFound, targetType.Elem(), name)
+	}
+	delete(c.bindings[targetType.Elem()], name)
+	return nil
+}
+
+// Bind registers a factory function in the container.
+// The resolver function's parameters will be automatically resolved when the return type is requested.
+// A resolver that is not a function, such as a string or a struct value, is bound as a constant
+// under its dynamic type.
+func (c *Container) Bind(resolver interface{}, options ...BindOption) error {
+	// Apply default configuration
+	config := &bindConfig{
+		name:      "",
+		singleton: true,
+		lazy:      true,
+	}
+
+	// Apply provided options
+	for _, option := range options {
+		option(config)
+	}
+
+	return c.bind(resolver, config)
+}
+
+// Resolve returns an instance by setting the value of the provided pointer.
+// The target must be a pointer to the type you want to resolve.
+// Options such as WithForceTransient change the behavior of this call only.
+func (c *Container) Resolve(target interface{}, options ...ResolveOption) error {
+	return c.resolveWith(context.Background(), target, "", options)
+}
+
+// ResolveContext is like Resolve but honors cancellation of ctx.
+// Factories that accept a context.Context parameter receive ctx, and ctx.Err() is returned
+// if the context is canceled before an instance is constructed.
+func (c *Container) ResolveContext(ctx context.Context, target interface{}) error {
+	return c.resolveNamed(c.begin(ctx), target, "", lifetimeDefault)
+}
+
+// ResolveNamed returns a named instance by setting the value of the provided pointer.
+// The target must be a pointer to the type you want to resolve.
+// Options such as WithForceTransient change the behavior of this call only.
+func (c *Container) ResolveNamed(target interface{}, name string, options ...ResolveOption) error {
+	return c.resolveWith(context.Background(), target, name, options)
+}
+
+// ResolveFirst resolves the first of the names that is bound for the target type, e.g. to prefer
+// a "regional" binding over a "global" one. Use the empty name for the default binding.
+func (c *Container) ResolveFirst(target interface{}, names ...string) error {
+	targetValue := reflect.ValueOf(target)
+	if err := checkTarget(targetValue); err != nil {
+		return err
+	}
+
+	targetType := targetValue.Elem().Type()
+	for _, name := range names {
+		if c.bound(targetType, name) {
+			return c.ResolveNamed(target, name)
+		}
+	}
+
+	return c.missing(fmt.Errorf("%w for type %s with any of the names '%s'", ErrBindingNotFound, targetType, strings.Join(names, "', '")))
+}
+
+// checkTarget returns an error unless the resolution target is a non-nil pointer the instance can be stored in.
+func checkTarget(targetValue reflect.Value) error {
+	if targetValue.Kind() != reflect.Ptr {
+		return ErrNotAPointer
+	}
+	if targetValue.IsNil() {
+		return ErrNilPointer
+	}
+	return nil
+}
+
+// assign stores the instance in the target, failing with a descriptive error instead of panicking
+// if the instance is not assignable to the target's type. A nil instance stores the zero value.
+func assign(target reflect.Value, instance any) error {
+	value := valueOf(instance, target.Type())
+	if !value.Type().AssignableTo(target.Type()) {
+		return fmt.Errorf("cannot assign instance of type %s to target of type %s", value.Type(), target.Type())
+	}
+	target.Set(value)
+	return nil
+}
+
+// bound reports whether resolving the type and name would find a binding, mirroring resolveNamed.
+func (c *Container) bound(t reflect.Type, name string) bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if b, _ := c.active(c.bindings[t][name]); b != nil {
+		return true
+	}
+	if t.Kind() == reflect.Struct {
+		if b, _ := c.active(c.bindings[reflect.PtrTo(t)][name]); b != nil {
+			return true
+		}
+	}
+	b, _ := c.autoAddrBinding(t, name)
+	return b != nil
+}
+
+// ResolveValue returns the instance of the type the target points to instead of storing it. The target only
+// describes the type and is not written to, so a typed nil pointer such as (*Database)(nil) is enough.
+func (c *Container) ResolveValue(target interface{}) (interface{}, error) {
+	targetType := reflect.TypeOf(target)
+	if targetType == nil || targetType.Kind() != reflect.Ptr {
+		return nil, ErrNotAPointer
+	}
+
+	return c.ResolveType(targetType.Elem(), "")
+}
+
+// MustResolve is like Resolve but panics if the resolution fails, for startup code where a missing
+// dependency is unrecoverable.
+func (c *Container) MustResolve(target interface{}) {
+	if err := c.Resolve(target); err != nil {
+		targetType := reflect.TypeOf(target)
+		if targetType != nil && targetType.Kind() == reflect.Ptr {
+			targetType = targetType.Elem()
+		}
+		panic(fmt.Sprintf("di: failed to resolve %v: %v", targetType, err))
+	}
+}
+
+// ResolveCoerce resolves the default binding of the type the source points to and assigns it to the target,
+// whose type may differ as long as the source type is assignable to it, e.g. a ReadWriteDB binding into a ReadOnlyDB.
+func (c *Container) ResolveCoerce(target interface{}, source interface{}) error {
+	targetValue, sourceType := reflect.ValueOf(target), reflect.TypeOf(source)
+	if targetValue.Kind() != reflect.Ptr || sourceType == nil || sourceType.Kind() != reflect.Ptr {
+		return ErrNotAPointer
+	}
+	if targetValue.IsNil() {
+		return ErrNilPointer
+	}
+
+	targetType := targetValue.Elem().Type()
+	if !sourceType.Elem().AssignableTo(targetType) {
+		return fmt.Errorf("cannot coerce %s to %s: not assignable", sourceType.Elem(), targetType)
+	}
+
+	instance := reflect.New(sourceType.Elem())
+	if err := c.resolveNamed(c.begin(context.Background()), instance.Interface(), "", lifetimeDefault); err != nil {
+		return err
+	}
+	targetValue.Elem().Set(instance.Elem())
+	return nil
+}
+
+// ResolveTransient constructs a new instance for the target even if the type is bound as a singleton.
+// The cached singleton instance, if any, is left untouched.
+func (c *Container) ResolveTransient(target interface{}) error {
+	return c.resolveNamed(c.begin(context.Background()), target, "", lifetimeTransient)
+}
+
+// ResolveSingleton returns a cached instance for the target even if the type is bound as transient.
+// The instance is constructed and cached on the first call.
+func (c *Container) ResolveSingleton(target interface{}) error {
+	return c.resolveNamed(c.begin(context.Background()), target, "", lifetimeSingleton)
+}
+
+func (c *Container) resolveNamed(r *resolution, target interface{}, name string, lt lifetime) error {
+	targetValue := reflect.ValueOf(target)
+	if err := checkTarget(targetValue); err != nil {
+		return err
+	}
+
+	instance, err := c.resolveType(r, targetValue.Elem().Type(), name, lt)
+	if err != nil {
+		return err
+	}
+	return assign(targetValue.Elem(), instance)
+}
+
+// ResolveType returns the instance of the binding registered under name for the type t, for callers that
+// only have a reflect.Type, such as frameworks built on the container. It resolves like ResolveNamed.
+func (c *Container) ResolveType(t reflect.Type, name string) (interface{}, error) {
+	if t == nil {
+		return nil, errors.New("container: cannot resolve a nil type")
+	}
+
+	instance, err := c.resolveType(c.begin(context.Background()), t, name, lifetimeDefault)
+	if err != nil {
+		return nil, err
+	}
+	value := reflect.New(t).Elem()
+	if err := assign(value, instance); err != nil {
+		return nil, err
+	}
+	return value.Interface(), nil
+}
+
+// resolveType returns the instance of the binding registered under name for the type t,
+// which the caller still has to check for assignability to t.
+func (c *Container) resolveType(r *resolution, t reflect.Type, name string, lt lifetime) (any, error) {
+	if err := r.ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Try to find a binding for the type directly.
+	if binding, err := c.lookup(t, name); err != nil {
+		return nil, err
+	} else if binding != nil {
+		return binding.resolveLifetime(c, r, lt)
+	}
+
+	// If the type is a struct, and we didn't find a binding,
+	// try to find a binding for a pointer to the type.
+	if t.Kind() == reflect.Struct {
+		if binding, err := c.lookup(reflect.PtrTo(t), name); err != nil {
+			return nil, err
+		} else if binding != nil {
+			instance, err := binding.resolveLifetime(c, r, lt)
+			if err != nil {
+				return nil, err
+			}
+			// instance is a pointer, so we dereference it.
+			ptr := valueOf(instance, binding.typ)
+			if ptr.IsNil() {
+				return nil, fmt.Errorf("cannot assign nil %s to target of type %s", binding.typ, t)
+			}
+			return ptr.Elem().Interface(), nil
+		}
+	}
+
+	// If the type is an interface, try a value binding whose address implements it.
+	c.lock.RLock()
+	binding, err := c.autoAddrBinding(t, name)
+	c.lock.RUnlock()
+	if err != nil {
+		return nil, err
+	} else if binding != nil {
+		return binding.obtain(c, r, lt)
+	}
+
+	if instance, supplied, err := c.supply(t, name); err != nil {
+		return nil, err
+	} else if supplied {
+		return instance.Interface(), nil
+	}
+
+	return nil, c.missing(fmt.Errorf("%w for type %s with name '%s'", ErrBindingNotFound, t.String(), name))
+}
+
+// ResolveAll returns all instances of a given type by setting the value of the provided pointer.
+// The target must be a pointer to a slice of the type you want to resolve.
+// Instances are returned in the order their bindings were registered.
+//
+// Bindings are matched on the exact element type, whatever their names, for interfaces and concrete
+// types alike: []*Worker collects every *Worker binding, and []Handler every Handler binding, but not
+// the bindings of concrete types that merely implement Handler.
+func (c *Container) ResolveAll(target interface{}) error {
+	targetValue := reflect.ValueOf(target)
+	if err := checkTarget(targetValue); err != nil {
+		return err
+	}
+	if targetValue.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("%w to a slice", ErrNotAPointer)
+	}
+
+	sliceType := targetValue.Elem().Type()
+	elemType := sliceType.Elem()
+
+	c.lock.RLock()
+	_, exists := c.bindings[elemType]
+	bindings := c.orderedBindings(elemType)
+	c.lock.RUnlock()
+
+	if exists {
+		r := c.begin(context.Background())
+		instances := reflect.MakeSlice(sliceType, 0, len(bindings))
+		for _, binding := range bindings {
+			instance, err := binding.resolve(c, r)
+			if err != nil {
+				return err
+			}
+			instances = reflect.Append(instances, valueOf(instance, elemType))
+		}
+		targetValue.Elem().Set(instances)
+		return nil
+	}
+
+	return nil
+}
+
+// ResolveCapable resolves every binding of the interface type ifacePtr points to, like ResolveAll,
+// and returns the instances that also implement the capability interface capabilityPtr points to,
+// e.g. every Handler that is also an io.Closer.
+func (c *Container) ResolveCapable(ifacePtr interface{}, capabilityPtr interface{}) ([]interface{}, error) {
+	ifaceType, capabilityType := reflect.TypeOf(ifacePtr), reflect.TypeOf(capabilityPtr)
+	if ifaceType == nil || ifaceType.Kind() != reflect.Ptr || capabilityType == nil || capabilityType.Kind() != reflect.Ptr {
+		return nil, ErrNotAPointer
+	}
+	if capabilityType.Elem().Kind() != reflect.Interface {
+		return nil, fmt.Errorf("capability %s is not an interface type", capabilityType.Elem())
+	}
+
+	instances := reflect.New(reflect.SliceOf(ifaceType.Elem()))
+	if err := c.ResolveAll(instances.Interface()); err != nil {
+		return nil, err
+	}
+
+	var capable []interface{}
+	for i := 0; i < instances.Elem().Len(); i++ {
+		instance := instances.Elem().Index(i).Interface()
+		if instance != nil && reflect.TypeOf(instance).Implements(capabilityType.Elem()) {
+			capable = append(capable, instance)
+		}
+	}
+	return capable, nil
+}
+
+// ResolveByTag returns every instance carrying the tag by setting the value of the provided pointer.
+// The target must be a pointer to a slice of the type you want to resolve.
+// Instances are returned in the order their bindings were registered.
+func (c *Container) ResolveByTag(tag string, target interface{}) error {
+	targetValue := reflect.ValueOf(target)
+	if err := checkTarget(targetValue); err != nil {
+		return err
+	}
+	if targetValue.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("%w to a slice", ErrNotAPointer)
+	}
+
+	sliceType := targetValue.Elem().Type()
+	c.lock.RLock()
+	bindings := c.orderedBindings(sliceType.Elem())
+	c.lock.RUnlock()
+
+	r := c.begin(context.Background())
+	instances := reflect.MakeSlice(sliceType, 0, 0)
+	for _, binding := range bindings {
+		if !binding.hasTag(tag) {
+			continue
+		}
+
+		instance, err := binding.resolve(c, r)
+		if err != nil {
+			return err
+		}
+		instances = reflect.Append(instances, valueOf(instance, sliceType.Elem()))
+	}
+
+	targetValue.Elem().Set(instances)
+	return nil
 }
 
-func (b *binding) resolve(c *Container) (any, error) {
-	// For singleton bindings, use mutex for thread safety
-	if b.singleton {
-		b.mutex.Lock()
-		defer b.mutex.Unlock()
+// ResolveAllNamed returns every instance of the type the target points to, keyed by binding name.
+// The default binding appears under the empty name.
+func (c *Container) ResolveAllNamed(target interface{}) (map[string]interface{}, error) {
+	targetType := reflect.TypeOf(target)
+	if targetType == nil || targetType.Kind() != reflect.Ptr {
+		return nil, ErrNotAPointer
+	}
 
-		// Check if we already have a cached instance
-		if b.concrete != nil {
-			return b.concrete, nil
-		}
+	c.lock.RLock()
+	bindings := c.orderedBindings(targetType.Elem())
+	c.lock.RUnlock()
 
-		// Create the instance
-		val, err := c.callResolver(b.resolver)
+	r := c.begin(context.Background())
+	instances := make(map[string]interface{}, len(bindings))
+	for _, binding := range bindings {
+		instance, err := binding.resolve(c, r)
 		if err != nil {
 			return nil, err
 		}
+		instances[binding.name] = instance
+	}
 
-		// Cache it for future use
-		b.concrete = val
-		return val, nil
+	return instances, nil
+}
+
+// FallbackUsed reports whether the binding for the target type and name was constructed by its fail-safe fallback.
+func (c *Container) FallbackUsed(target interface{}, name string) bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	targetType := reflect.TypeOf(target)
+	if targetType == nil || targetType.Kind() != reflect.Ptr {
+		return false
 	}
 
-	// For transient bindings, just create a new instance each time
-	return c.callResolver(b.resolver)
+	if binding, _ := c.active(c.bindings[targetType.Elem()][name]); binding != nil {
+		return binding.state().fallbackUsed.Load()
+	}
+	return false
 }
 
-type Container struct {
-	bindings map[reflect.Type]map[string]*binding
-	lock     sync.RWMutex
+// BindTransient is a convenience method for binding a transient instance
+func (c *Container) BindTransient(resolver interface{}, options ...BindOption) error {
+	allOptions := append([]BindOption{WithTransient()}, options...)
+	return c.Bind(resolver, allOptions...)
 }
 
-func New() *Container {
-	return &Container{
-		bindings: make(map[reflect.Type]map[string]*binding),
+// BindNamed is a convenience method for binding with a name
+func (c *Container) BindNamed(name string, resolver interface{}, options ...BindOption) error {
+	allOptions := append([]BindOption{WithName(name)}, options...)
+	return c.Bind(resolver, allOptions...)
+}
+
+// BindNamedTransient is a convenience method for binding a named transient instance
+func (c *Container) BindNamedTransient(name string, resolver interface{}, options ...BindOption) error {
+	allOptions := append([]BindOption{WithName(name), WithTransient()}, options...)
+	return c.Bind(resolver, allOptions...)
+}
+
+// calls the resolver function, resolving its arguments unless they are provided
+func (c *Container) callResolver(r *resolution, function interface{}, arguments []reflect.Value) ([]reflect.Value, error) {
+	if arguments == nil {
+		var err error
+		if arguments, err = c.resolveArguments(r, paramTypes(reflect.TypeOf(function)), nil); err != nil {
+			return nil, err
+		}
 	}
+
+	value := reflect.ValueOf(function)
+	return callRecovered("constructing", value.Type().Out(0), value, arguments)
 }
 
-func (c *Container) Clear() {
-	c.lock.Lock()
-	defer c.lock.Unlock()
-	c.bindings = make(map[reflect.Type]map[string]*binding)
+// callResults calls a factory-shaped function, splitting its results into the instances and an optional trailing error.
+func callResults(function reflect.Value, arguments []reflect.Value) ([]reflect.Value, error) {
+	values := function.Call(arguments)
+	last := len(values) - 1
+	if function.Type().Out(last) != errorType {
+		return values, nil
+	}
+	if !values[last].IsNil() {
+		return nil, values[last].Interface().(error)
+	}
+	return values[:last], nil
 }
 
-// Bind registers a factory function in the container.
-// The resolver function's parameters will be automatically resolved when the return type is requested.
-func (c *Container) Bind(resolver interface{}, options ...BindOption) error {
-	c.lock.Lock()
-	defer c.lock.Unlock()
+// invoke calls the binding's resolver with the given arguments, converting a panic in the factory
+// into an error naming the type under construction.
+func (b *binding) invoke(arguments []reflect.Value) ([]reflect.Value, error) {
+	return callRecovered("constructing", b.typ, reflect.ValueOf(b.resolver), arguments)
+}
 
-	// Apply default configuration
-	config := &bindConfig{
-		name:      "",
-		singleton: true,
-		lazy:      true,
+// callRecovered is callResults converting a panic in the function into an error naming what it was doing
+// and the type it was doing it for, e.g. "panic while decorating di.Database: boom".
+func callRecovered(doing string, t reflect.Type, function reflect.Value, arguments []reflect.Value) (values []reflect.Value, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			values, err = nil, fmt.Errorf("panic while %s %s: %v", doing, t, p)
+		}
+	}()
+	return callResults(function, arguments)
+}
+
+// paramTypes returns the parameter types of a function type.
+func paramTypes(funcType reflect.Type) []reflect.Type {
+	types := make([]reflect.Type, funcType.NumIn())
+	for i := range types {
+		types[i] = funcType.In(i)
 	}
+	return types
+}
 
-	// Apply provided options
-	for _, option := range options {
-		option(config)
+// resultTypes returns the types a resolver produces, which are its results without a trailing error.
+func resultTypes(funcType reflect.Type) []reflect.Type {
+	count := funcType.NumOut()
+	if count > 0 && funcType.Out(count-1) == errorType {
+		count--
 	}
 
-	return c.bind(resolver, config.name, config.singleton, config.lazy)
+	types := make([]reflect.Type, count)
+	for i := range types {
+		types[i] = funcType.Out(i)
+	}
+	return types
 }
 
-// Resolve returns an instance by setting the value of the provided pointer.
-// The target must be a pointer to the type you want to resolve.
-func (c *Container) Resolve(target interface{}) error {
-	return c.ResolveNamed(target, "")
+// callRecover calls the function, converting a panic into an error.
+func callRecover(function func() (any, error)) (value any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			value, err = nil, fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return function()
 }
 
-// ResolveNamed returns a named instance by setting the value of the provided pointer.
-// The target must be a pointer to the type you want to resolve.
-func (c *Container) ResolveNamed(target interface{}, name string) error {
+// resolveArguments returns the list of resolved arguments for a function with the given parameter types.
+// Parameters with a non-empty name in names are resolved from the binding with that name.
+func (c *Container) resolveArguments(r *resolution, in []reflect.Type, names []string) ([]reflect.Value, error) {
+	arguments := make([]reflect.Value, len(in))
+
+	for i, argType := range in {
+		var argument reflect.Value
+		var err error
+		if i < len(names) && names[i] != "" {
+			argument, err = c.resolveNamedArgument(r, argType, names[i])
+		} else {
+			argument, err = c.resolveArgument(r, argType)
+		}
+		if err != nil {
+			return nil, err
+		}
+		arguments[i] = argument
+	}
+
+	return arguments, nil
+}
+
+// resolveNamedArgument returns the value injected for a parameter mapped to a named binding.
+func (c *Container) resolveNamedArgument(r *resolution, argType reflect.Type, name string) (reflect.Value, error) {
+	bound, err := c.lookup(argType, name)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if bound == nil {
+		if instance, supplied, err := c.supply(argType, name); err != nil || supplied {
+			return instance, err
+		}
+		return reflect.Value{}, c.missing(fmt.Errorf("failed resolving %s: %w for %s with name '%s'", r.path(argType), ErrBindingNotFound, argType, name))
+	}
+
+	instance, err := bound.resolve(c, r)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return valueOf(instance, argType), nil
+}
+
+// resolveArgument returns the value injected for a single function parameter.
+func (c *Container) resolveArgument(r *resolution, argType reflect.Type) (reflect.Value, error) {
+	// Factories may accept the context of the current resolution.
+	if argType == contextType {
+		return reflect.ValueOf(r.ctx), nil
+	}
+
+	// Factories may accept the container itself to resolve dynamically.
+	if argType == containerType {
+		return reflect.ValueOf(c.within(r)), nil
+	}
+
+	// Per-call overrides take precedence over bindings.
+	if override, exists := r.overrides[argType]; exists {
+		r.usedOverride = true
+		return override, nil
+	}
+
+	// Lazy[T] parameters never need a binding of their own.
+	if isLazy(argType) {
+		return newLazy(argType, c.within(r)), nil
+	}
+
+	// Providers[T] parameters receive a provider for every binding of T.
+	if isProviders(argType) {
+		return c.within(r).newProviders(argType), nil
+	}
+
+	// Optional[T] parameters are left empty if T is not bound.
+	if isOptional(argType) {
+		return c.resolveOptional(r, argType)
+	}
+
+	if bound, err := c.lookup(argType, ""); err != nil {
+		return reflect.Value{}, err
+	} else if bound != nil {
+		instance, err := bound.resolve(c, r)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(instance), nil
+	}
+
 	c.lock.RLock()
-	defer c.lock.RUnlock()
+	bound, err := c.autoAddrBinding(argType, "")
+	c.lock.RUnlock()
+	if err != nil {
+		return reflect.Value{}, err
+	} else if bound != nil {
+		instance, err := bound.obtain(c, r, lifetimeDefault)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(instance), nil
+	}
 
-	targetValue := reflect.ValueOf(target)
-	if targetValue.Kind() != reflect.Ptr {
-		return fmt.Errorf("target must be a pointer")
+	// Interfaces may be satisfied by a concrete binding implementing them, if enabled.
+	c.lock.RLock()
+	bound, err = c.implementationBinding(argType)
+	c.lock.RUnlock()
+	if err != nil {
+		return reflect.Value{}, err
+	} else if bound != nil {
+		instance, err := bound.resolve(c, r)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return valueOf(instance, argType), nil
 	}
 
-	targetType := targetValue.Elem().Type()
+	// Provider functions resolve T on every call, e.g. to construct transient instances on demand.
+	if isProviderFunc(argType) {
+		return c.within(r).newProviderFunc(argType), nil
+	}
 
-	// Try to find a binding for the target type directly.
-	if bindings, exists := c.bindings[targetType]; exists {
-		if binding, exists := bindings[name]; exists {
-			instance, err := binding.resolve(c)
-			if err != nil {
-				return err
+	// Slice parameters collect every binding of the element type.
+	if argType.Kind() == reflect.Slice {
+		c.lock.RLock()
+		bindings := c.orderedBindings(argType.Elem())
+		c.lock.RUnlock()
+
+		if len(bindings) > 0 {
+			instances := reflect.MakeSlice(argType, 0, len(bindings))
+			for _, bound := range bindings {
+				instance, err := bound.resolve(c, r)
+				if err != nil {
+					return reflect.Value{}, err
+				}
+				instances = reflect.Append(instances, valueOf(instance, argType.Elem()))
 			}
-			targetValue.Elem().Set(reflect.ValueOf(instance))
-			return nil
+			return instances, nil
 		}
 	}
 
-	// If the target is a struct, and we didn't find a binding,
-	// try to find a binding for a pointer to the target type.
-	if targetType.Kind() == reflect.Struct {
-		ptrType := reflect.PtrTo(targetType)
-		if bindings, exists := c.bindings[ptrType]; exists {
-			if binding, exists := bindings[name]; exists {
-				instance, err := binding.resolve(c)
+	// Map parameters with string keys collect every binding of the element type by name.
+	if isNamedMap(argType) {
+		c.lock.RLock()
+		bindings := c.orderedBindings(argType.Elem())
+		c.lock.RUnlock()
+
+		if len(bindings) > 0 {
+			instances := reflect.MakeMapWithSize(argType, len(bindings))
+			for _, bound := range bindings {
+				instance, err := bound.resolve(c, r)
 				if err != nil {
-					return err
+					return reflect.Value{}, err
 				}
-				// instance is a pointer, so we dereference it.
-				targetValue.Elem().Set(reflect.ValueOf(instance).Elem())
-				return nil
+				instances.SetMapIndex(reflect.ValueOf(bound.name).Convert(argType.Key()), valueOf(instance, argType.Elem()))
 			}
+			return instances, nil
 		}
 	}
 
-	return fmt.Errorf("no binding found for type %s with name '%s'", targetType.String(), name)
+	if instance, supplied, err := c.supply(argType, ""); err != nil || supplied {
+		return instance, err
+	}
+
+	return reflect.Value{}, c.missing(fmt.Errorf("failed resolving %s: %w for %s", r.path(argType), ErrBindingNotFound, argType))
 }
 
-// ResolveAll returns all instances of a given type by setting the value of the provided pointer.
-// The target must be a pointer to a slice of the type you want to resolve.
-func (c *Container) ResolveAll(target interface{}) error {
-	c.lock.RLock()
-	defer c.lock.RUnlock()
+// isNamedMap reports whether a parameter type is a map with string keys, which receives bindings by name.
+func isNamedMap(argType reflect.Type) bool {
+	return argType.Kind() == reflect.Map && argType.Key().Kind() == reflect.String
+}
 
-	targetValue := reflect.ValueOf(target)
-	if targetValue.Kind() != reflect.Ptr || targetValue.Elem().Kind() != reflect.Slice {
-		return fmt.Errorf("target must be a pointer to a slice")
+// bind maps an abstraction to concrete and instantiates if it is a singleton binding.
+// A resolver with several results registers one binding per result type, see sharedCall.
+// Eager instances are constructed before the binding is registered and without holding the lock,
+// so a failing factory leaves the container unchanged.
+func (c *Container) bind(resolver interface{}, config *bindConfig) error {
+	if resolver == nil {
+		return fmt.Errorf("container: the resolver %w", ErrNotAFunction)
 	}
 
-	sliceType := targetValue.Elem().Type()
-	elemType := sliceType.Elem()
+	// Values other than functions are constants, resolved under their dynamic type
+	if reflect.TypeOf(resolver).Kind() != reflect.Func {
+		resolver = constant(reflect.ValueOf(resolver))
+	}
 
-	if bindings, exists := c.bindings[elemType]; exists {
-		instances := reflect.MakeSlice(sliceType, 0, len(bindings))
-		for _, binding := range bindings {
-			instance, err := binding.resolve(c)
-			if err != nil {
-				return err
+	reflectedResolver := reflect.TypeOf(resolver)
+
+	if err := c.validateResolverFunction(reflectedResolver); err != nil {
+		return err
+	}
+
+	if config.nameAware && (reflectedResolver.NumIn() == 0 || reflectedResolver.In(0).Kind() != reflect.String) {
+		return errors.New("name-aware factory must take the name as its first parameter")
+	}
+
+	if len(config.params) > reflectedResolver.NumIn() {
+		return fmt.Errorf("%d parameter names given for a resolver with %d parameters", len(config.params), reflectedResolver.NumIn())
+	}
+
+	if config.fallback != nil {
+		if err := c.validateFallback(reflectedResolver, reflect.TypeOf(config.fallback)); err != nil {
+			return err
+		}
+	}
+
+	if config.spread {
+		return c.spread(resolver, config)
+	}
+
+	types := resultTypes(reflectedResolver)
+
+	c.lock.Lock()
+	seq := c.seq
+	c.seq += uint64(len(types))
+	deferEager := c.deferEager
+	inactive := config.profile != "" && !c.profiles[config.profile]
+	c.lock.Unlock()
+
+	var shared *sharedCall
+	if len(types) > 1 {
+		shared = &sharedCall{pending: make(map[int]any)}
+	}
+
+	bindings := make([]*binding, len(types))
+	for i, typ := range types {
+		seq++
+		name := config.name
+		if name == "" && config.group != "" {
+			name = fmt.Sprintf("%s#%d", config.group, seq)
+		}
+
+		bindings[i] = config.newBinding(typ, name, seq, resolver, i, shared)
+		if config.nameAware {
+			bindings[i].template = config
+		}
+	}
+	if shared != nil {
+		shared.siblings = bindings
+	}
+
+	if (!config.lazy || config.background) && !config.nameAware && !inactive && (config.condition == nil || config.condition()) {
+		for _, b := range bindings {
+			if config.background {
+				b.ready = make(chan struct{})
+			} else if deferEager {
+				b.eager = true
+			} else if _, err := b.obtain(c, c.begin(context.Background()), lifetimeDefault); err != nil {
+				if !b.bestEffort {
+					return fmt.Errorf("eager construction of %s failed: %w", b.typ, err)
+				}
+				c.skip(b, err)
 			}
-			instances = reflect.Append(instances, reflect.ValueOf(instance))
 		}
-		targetValue.Elem().Set(instances)
-		return nil
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, b := range bindings {
+		if _, exist := c.bindings[b.typ]; !exist {
+			c.bindings[b.typ] = make(map[string]*binding)
+		}
+		if b.condition != nil || b.profile != "" || b.ranked {
+			b.next = c.bindings[b.typ][b.name]
+		}
+		c.bindings[b.typ][b.name] = b
+	}
+
+	// Background constructions wait for the lock, so they start once the bindings are registered
+	for _, b := range bindings {
+		if b.ready != nil {
+			go b.constructInBackground(c)
+		}
 	}
 
 	return nil
 }
 
-// BindTransient is a convenience method for binding a transient instance
-func (c *Container) BindTransient(resolver interface{}, options ...BindOption) error {
-	allOptions := append([]BindOption{WithTransient()}, options...)
-	return c.Bind(resolver, allOptions...)
+// constant returns a factory function without parameters that returns the value.
+func constant(v reflect.Value) interface{} {
+	funcType := reflect.FuncOf(nil, []reflect.Type{v.Type()}, false)
+	return reflect.MakeFunc(funcType, func([]reflect.Value) []reflect.Value {
+		return []reflect.Value{v}
+	}).Interface()
 }
 
-// BindNamed is a convenience method for binding with a name
-func (c *Container) BindNamed(name string, resolver interface{}, options ...BindOption) error {
-	allOptions := append([]BindOption{WithName(name)}, options...)
-	return c.Bind(resolver, allOptions...)
+// newBinding creates a binding for the resolver result at index out, configured by config.
+func (config *bindConfig) newBinding(typ reflect.Type, name string, seq uint64, resolver any, out int, shared *sharedCall) *binding {
+	return &binding{
+		typ:        typ,
+		name:       name,
+		labels:     config.labels,
+		group:      config.group,
+		tags:       config.tags,
+		seq:        seq,
+		resolver:   resolver,
+		in:         paramTypes(reflect.TypeOf(resolver)),
+		params:     config.params,
+		out:        out,
+		shared:     shared,
+		fallback:   config.fallback,
+		singleton:  config.singleton,
+		autoAddr:   config.autoAddr,
+		keyFunc:    config.keyFunc,
+		ctxKey:     config.ctxKey,
+		keyed:      keyedCache{maxSize: config.cacheSize},
+		precond:    config.precond,
+		validate:   config.validate,
+		condition:  config.condition,
+		profile:    config.profile,
+		reactive:   config.reactive,
+		onStart:    config.onStart,
+		onStop:     config.onStop,
+		timeout:    config.timeout,
+		scoped:     config.scoped,
+		bestEffort: config.bestEffort,
+		typed:      config.typed,
+		weak:       config.weak,
+		clone:      config.clone,
+		priority:   config.priority,
+		ranked:     config.ranked,
+		slots:      newSlots(config.slots),
+		config:     config,
+	}
 }
 
-// BindNamedTransient is a convenience method for binding a named transient instance
-func (c *Container) BindNamedTransient(name string, resolver interface{}, options ...BindOption) error {
-	allOptions := append([]BindOption{WithName(name), WithTransient()}, options...)
-	return c.Bind(resolver, allOptions...)
+func (c *Container) validateResolverFunction(funcType reflect.Type) error {
+	resolveTypes := resultTypes(funcType)
+	if len(resolveTypes) == 0 {
+		return errors.New("need at least one return value besides an optional error")
+	}
+
+	for _, resolveType := range resolveTypes {
+		for i := 0; i < funcType.NumIn(); i++ {
+			if funcType.In(i) == resolveType {
+				return fmt.Errorf("can't depend on return type")
+			}
+		}
+	}
+
+	return nil
 }
 
-// calls the resolver function
-func (c *Container) callResolver(function interface{}) (interface{}, error) {
-	arguments, err := c.resolveArguments(function)
-	if err != nil {
-		return nil, err
+func (c *Container) validateFallback(resolverType, fallbackType reflect.Type) error {
+	if fallbackType == nil || fallbackType.Kind() != reflect.Func {
+		return fmt.Errorf("container: the fallback %w", ErrNotAFunction)
 	}
 
-	values := reflect.ValueOf(function).Call(arguments)
-	if len(values) == 2 && values[1].CanInterface() {
-		if err, ok := values[1].Interface().(error); ok {
-			return values[0].Interface(), err
+	if err := c.validateResolverFunction(fallbackType); err != nil {
+		return err
+	}
+
+	resolveTypes, fallbackTypes := resultTypes(resolverType), resultTypes(fallbackType)
+	if len(fallbackTypes) != len(resolveTypes) {
+		return fmt.Errorf("fallback returns %d values, but the resolver returns %d", len(fallbackTypes), len(resolveTypes))
+	}
+	for i, resolveType := range resolveTypes {
+		if !fallbackTypes[i].AssignableTo(resolveType) {
+			return fmt.Errorf("fallback returns %s, which is not assignable to %s", fallbackTypes[i], resolveType)
 		}
 	}
-	return values[0].Interface(), nil
+
+	return nil
 }
 
-// arguments returns the list of resolved arguments for a function.
-func (c *Container) resolveArguments(function interface{}) ([]reflect.Value, error) {
-	refFunc := reflect.TypeOf(function)
-	argNum := refFunc.NumIn()
-	arguments := make([]reflect.Value, argNum)
+// lookup returns the active binding registered for the type and name, or nil if there is none.
+func (c *Container) lookup(t reflect.Type, name string) (*binding, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	b, err := c.active(c.bindings[t][name])
+	if err != nil || b != nil || name == "" {
+		return b, err
+	}
 
-	for i := 0; i < argNum; i++ {
-		argType := refFunc.In(i)
+	// A name-aware default binding answers every name without a binding of its own
+	if fallback, _ := c.active(c.bindings[t][""]); fallback != nil && fallback.parent != nil {
+		return fallback.parent.forName(name), nil
+	}
+	return nil, nil
+}
 
-		if isLazy(argType) {
-			lazyValue := reflect.New(argType).Elem()
-			lazyValue.FieldByName("Container").Set(reflect.ValueOf(c))
-			arguments[i] = lazyValue
+// active returns the candidate in the chain starting at b that currently takes effect, or nil if there is none.
+// Only the eligible candidates with the highest priority compete, and several of them registered with
+// WithPriority are ambiguous. Among them, a candidate of an active profile takes precedence over candidates
+// without a profile, and several of them are ambiguous. Otherwise, the earliest registered candidate whose
+// condition holds is chosen. The caller must hold c.lock.
+func (c *Container) active(b *binding) (*binding, error) {
+	var found, profiled, ranked *binding
+	var ambiguous error
+	top, eligible := 0, false
+	for candidate := b; candidate != nil; candidate = candidate.next {
+		if candidate.condition != nil && !candidate.condition() || candidate.profile != "" && !c.profiles[candidate.profile] {
 			continue
 		}
 
-		if bound, exist := c.bindings[argType][""]; exist {
-			instance, err := bound.resolve(c)
-			if err != nil {
-				return nil, err
+		switch {
+		case !eligible || candidate.priority > top:
+			found, profiled, ranked, ambiguous = nil, nil, nil, nil
+			top, eligible = candidate.priority, true
+		case candidate.priority < top:
+			continue
+		}
+
+		if candidate.ranked {
+			if ranked != nil && ambiguous == nil {
+				ambiguous = fmt.Errorf("ambiguous bindings for type %s with name '%s': several bindings have priority %d", b.typ, b.name, top)
 			}
-			arguments[i] = reflect.ValueOf(instance)
-		} else {
-			return nil, errors.New("failed resolving argument " + argType.String())
+			ranked = candidate
+		}
+		switch {
+		case candidate.profile == "":
+			found = candidate
+		case profiled != nil:
+			if ambiguous == nil {
+				ambiguous = fmt.Errorf("ambiguous bindings for type %s with name '%s': profiles '%s' and '%s' are both active", b.typ, b.name, candidate.profile, profiled.profile)
+			}
+		default:
+			profiled = candidate
 		}
 	}
 
-	return arguments, nil
-}
-
-// bind maps an abstraction to concrete and instantiates if it is a singleton binding.
-func (c *Container) bind(resolver interface{}, name string, isSingleton bool, isLazy bool) error {
-	reflectedResolver := reflect.TypeOf(resolver)
-	if reflectedResolver.Kind() != reflect.Func {
-		return errors.New("container: the resolver must be a function")
+	if ambiguous != nil {
+		return nil, ambiguous
 	}
-
-	if reflectedResolver.NumOut() > 0 {
-		if _, exist := c.bindings[reflectedResolver.Out(0)]; !exist {
-			c.bindings[reflectedResolver.Out(0)] = make(map[string]*binding)
-		}
+	if profiled != nil {
+		found = profiled
 	}
-
-	if err := c.validateResolverFunction(reflectedResolver); err != nil {
-		return err
+	if found != nil && found.template != nil {
+		return found.forName(found.name), nil
 	}
+	return found, nil
+}
 
-	var concrete interface{}
-	if !isLazy {
-		var err error
-		concrete, err = c.callResolver(resolver)
-		if err != nil {
-			return err
+// orderedBindings returns every binding of the given type in registration order.
+// The caller must hold c.lock.
+func (c *Container) orderedBindings(t reflect.Type) []*binding {
+	bindings := make([]*binding, 0, len(c.bindings[t]))
+	for _, b := range c.bindings[t] {
+		if b, _ = c.active(b); b != nil {
+			bindings = append(bindings, b)
 		}
 	}
+	sort.Slice(bindings, func(i, j int) bool {
+		return bindings[i].before(bindings[j])
+	})
+	return bindings
+}
 
-	if isSingleton {
-		c.bindings[reflectedResolver.Out(0)][name] = &binding{resolver: resolver, concrete: concrete, singleton: isSingleton}
-	} else {
-		c.bindings[reflectedResolver.Out(0)][name] = &binding{resolver: resolver, singleton: isSingleton}
+// before reports whether b was registered before other. Bindings sharing a sequence number, such as
+// the bindings created per name from a name-aware binding, are ordered by type and name.
+func (b *binding) before(other *binding) bool {
+	if b.seq != other.seq {
+		return b.seq < other.seq
 	}
-
-	return nil
+	if b.typ != other.typ {
+		return b.typ.String() < other.typ.String()
+	}
+	return b.name < other.name
 }
 
-func (c *Container) validateResolverFunction(funcType reflect.Type) error {
-	retCount := funcType.NumOut()
+// state returns the binding holding the instances of b, which is b unless b was copied by Merge.
+func (b *binding) state() *binding {
+	if b.origin != nil {
+		return b.origin
+	}
+	return b
+}
 
-	if retCount == 0 || retCount > 2 {
-		return errors.New("need exactly one or two return values")
+// autoAddrBinding finds the auto-addressed binding with the given name whose pointer type implements the interface t.
+// It returns nil if there is none and an error if several bindings qualify. The caller must hold c.lock.
+func (c *Container) autoAddrBinding(t reflect.Type, name string) (*binding, error) {
+	if t.Kind() != reflect.Interface {
+		return nil, nil
 	}
 
-	resolveType := funcType.Out(0)
-	for i := 0; i < funcType.NumIn(); i++ {
-		if funcType.In(i) == resolveType {
-			return fmt.Errorf("can't depend on return type")
+	var found *binding
+	for bindingType, bindings := range c.bindings {
+		b, _ := c.active(bindings[name])
+		if b == nil || !b.autoAddr || !reflect.PtrTo(bindingType).Implements(t) {
+			continue
 		}
+		if found != nil {
+			return nil, fmt.Errorf("ambiguous auto-addressed bindings for type %s with name '%s': %s and %s", t, name, found.typ, b.typ)
+		}
+		found = b
 	}
+	return found, nil
+}
 
-	return nil
+// allBindings returns every active binding of every type in registration order.
+// The caller must hold c.lock.
+func (c *Container) allBindings() []*binding {
+	var all []*binding
+	for _, bindings := range c.bindings {
+		for _, b := range bindings {
+			if b, _ = c.active(b); b != nil {
+				all = append(all, b)
+			}
+		}
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].before(all[j])
+	})
+	return all
 }
@@ -2,7 +2,13 @@ package di
 
 import (
 	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -75,10 +81,10 @@ func TestContainer_Bind(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
-	t.Run("error when resolver is not a function", func(t *testing.T) {
+	t.Run("error when resolver is nil", func(t *testing.T) {
 		container := New()
 
-		err := container.Bind("not a function")
+		err := container.Bind(nil)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "resolver must be a function")
@@ -92,18 +98,18 @@ func TestContainer_Bind(t *testing.T) {
 		})
 
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "need exactly one or two return values")
+		assert.Contains(t, err.Error(), "need at least one return value besides an optional error")
 	})
 
-	t.Run("error when function has too many return values", func(t *testing.T) {
+	t.Run("error when function only returns an error", func(t *testing.T) {
 		container := New()
 
-		err := container.Bind(func() (Database, Logger, error) {
-			return nil, nil, nil
+		err := container.Bind(func() error {
+			return nil
 		})
 
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "need exactly one or two return values")
+		assert.Contains(t, err.Error(), "need at least one return value besides an optional error")
 	})
 
 	t.Run("allow function with error return", func(t *testing.T) {
@@ -128,6 +134,39 @@ func TestContainer_Bind(t *testing.T) {
 	})
 }
 
+func TestContainer_BindValue(t *testing.T) {
+	type serverConfig struct {
+		Host string
+		Port int
+	}
+
+	container := New()
+	require.NoError(t, container.Bind("postgres://localhost/app"))
+	require.NoError(t, container.Bind(42))
+	require.NoError(t, container.Bind(serverConfig{Host: "localhost", Port: 8080}))
+
+	var dsn string
+	require.NoError(t, container.Resolve(&dsn))
+	assert.Equal(t, "postgres://localhost/app", dsn)
+
+	var workers int
+	require.NoError(t, container.Resolve(&workers))
+	assert.Equal(t, 42, workers)
+
+	var config serverConfig
+	require.NoError(t, container.Resolve(&config))
+	assert.Equal(t, serverConfig{Host: "localhost", Port: 8080}, config)
+
+	// Constants are injected like any other binding
+	err := container.Bind(func(config serverConfig) Logger {
+		return &loggerImpl{messages: []string{config.Host}}
+	})
+	require.NoError(t, err)
+	var logger Logger
+	require.NoError(t, container.Resolve(&logger))
+	assert.Equal(t, []string{"localhost"}, logger.(*loggerImpl).messages)
+}
+
 func TestContainer_BindWithOptions(t *testing.T) {
 	t.Run("bind with explicit singleton option", func(t *testing.T) {
 		container := New()
@@ -210,6 +249,66 @@ func TestContainer_BindWithOptions(t *testing.T) {
 		assert.True(t, called)
 	})
 
+	t.Run("eager factory resolving from the same container", func(t *testing.T) {
+		container := New()
+
+		err := container.Bind(func() Database {
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		done := make(chan error, 1)
+		go func() {
+			done <- container.Bind(func() UserService {
+				var db Database
+				err := container.Resolve(&db)
+				assert.NoError(t, err)
+				return &userServiceImpl{db: db}
+			}, WithEager())
+		}()
+
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("eager binding deadlocked")
+		}
+
+		var userService UserService
+		require.NoError(t, container.Resolve(&userService))
+		assert.NotNil(t, userService.(*userServiceImpl).db)
+	})
+
+	t.Run("failed eager binding is not registered", func(t *testing.T) {
+		container := New()
+
+		refused := errors.New("connection refused")
+		err := container.Bind(func() (Database, error) {
+			return nil, refused
+		}, WithEager())
+		assert.EqualError(t, err, "eager construction of di.Database failed: connection refused")
+		assert.ErrorIs(t, err, refused)
+
+		var db Database
+		assert.ErrorIs(t, container.Resolve(&db), ErrBindingNotFound)
+		assert.Empty(t, container.Bindings())
+	})
+
+	t.Run("failed eager binding leaves the previous binding in place", func(t *testing.T) {
+		container := New()
+		original := &mockDatabase{}
+		require.NoError(t, container.Bind(func() Database { return original }))
+
+		err := container.Bind(func() (Database, error) {
+			return nil, errors.New("connection refused")
+		}, WithEager())
+		assert.ErrorContains(t, err, "eager construction of di.Database failed")
+
+		var db Database
+		require.NoError(t, container.Resolve(&db))
+		assert.Same(t, original, db)
+	})
+
 	t.Run("bind with lazy option (default)", func(t *testing.T) {
 		container := New()
 
@@ -359,6 +458,290 @@ func TestContainer_NamedResolution(t *testing.T) {
 	})
 }
 
+func TestContainer_ResolveFirst(t *testing.T) {
+	t.Run("first missing name is skipped", func(t *testing.T) {
+		container := New()
+		global := &mockDatabase{}
+		err := container.BindNamed("global", func() Database {
+			return global
+		})
+		require.NoError(t, err)
+		err = container.Bind(func() Database {
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		var db Database
+		require.NoError(t, container.ResolveFirst(&db, "regional", "global", ""))
+		assert.Same(t, global, db)
+	})
+
+	t.Run("default binding as last resort", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func() Database {
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		var db Database
+		require.NoError(t, container.ResolveFirst(&db, "regional", "global", ""))
+		assert.NotNil(t, db)
+	})
+
+	t.Run("error lists the tried names", func(t *testing.T) {
+		container := New()
+
+		var db Database
+		err := container.ResolveFirst(&db, "regional", "global")
+		assert.ErrorIs(t, err, ErrBindingNotFound)
+		assert.EqualError(t, err, "no binding found for type di.Database with any of the names 'regional', 'global'")
+	})
+}
+
+func TestContainer_ContainerParameter(t *testing.T) {
+	container := New()
+	replica := &mockDatabase{}
+	err := container.BindNamed("primary", func() Database {
+		return &mockDatabase{}
+	})
+	require.NoError(t, err)
+	err = container.BindNamed("replica", func() Database {
+		return replica
+	})
+	require.NoError(t, err)
+
+	readOnly := true
+	err = container.Bind(func(c *Container) (UserService, error) {
+		name := "primary"
+		if readOnly {
+			name = "replica"
+		}
+
+		var db Database
+		if err := c.ResolveNamed(&db, name); err != nil {
+			return nil, err
+		}
+		return &userServiceImpl{db: db}, nil
+	})
+	require.NoError(t, err)
+
+	var userService UserService
+	require.NoError(t, container.Resolve(&userService))
+	assert.Same(t, replica, userService.(*userServiceImpl).db)
+	assert.NoError(t, container.Validate())
+}
+
+type Repo struct {
+	primary Database
+	replica Database
+}
+
+func TestContainer_WithParamNames(t *testing.T) {
+	newContainer := func(t *testing.T) (*Container, Database, Database) {
+		container := New()
+		primary, replica := &mockDatabase{}, &mockDatabase{}
+		require.NoError(t, container.BindNamed("primary", func() Database { return primary }))
+		require.NoError(t, container.BindNamed("replica", func() Database { return replica }))
+		return container, primary, replica
+	}
+
+	t.Run("parameters are resolved by name", func(t *testing.T) {
+		container, primary, replica := newContainer(t)
+		err := container.Bind(func(primary Database, replica Database) *Repo {
+			return &Repo{primary: primary, replica: replica}
+		}, WithParamNames("primary", "replica"))
+		require.NoError(t, err)
+
+		var repo *Repo
+		require.NoError(t, container.Resolve(&repo))
+		assert.Same(t, primary, repo.primary)
+		assert.Same(t, replica, repo.replica)
+
+		logger := &recordingLogger{}
+		container.SetLogger(logger)
+		assert.NoError(t, container.Validate())
+		assert.Empty(t, logger.messages)
+	})
+
+	t.Run("empty name keeps the default", func(t *testing.T) {
+		container, _, replica := newContainer(t)
+		fallback := &mockDatabase{}
+		require.NoError(t, container.Bind(func() Database { return fallback }))
+		err := container.Bind(func(primary Database, replica Database) *Repo {
+			return &Repo{primary: primary, replica: replica}
+		}, WithParamNames("", "replica"))
+		require.NoError(t, err)
+
+		var repo *Repo
+		require.NoError(t, container.Resolve(&repo))
+		assert.Same(t, fallback, repo.primary)
+		assert.Same(t, replica, repo.replica)
+	})
+
+	t.Run("missing named binding", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func(primary Database) *Repo {
+			return &Repo{primary: primary}
+		}, WithParamNames("primary"))
+		require.NoError(t, err)
+
+		var repo *Repo
+		err = container.Resolve(&repo)
+		assert.ErrorIs(t, err, ErrBindingNotFound)
+		assert.EqualError(t, err, "failed resolving *di.Repo -> di.Database: no binding found for di.Database with name 'primary'")
+		assert.ErrorIs(t, container.Validate(), ErrBindingNotFound)
+	})
+
+	t.Run("more names than parameters", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func(primary Database) *Repo {
+			return &Repo{primary: primary}
+		}, WithParamNames("primary", "replica"))
+		assert.EqualError(t, err, "2 parameter names given for a resolver with 1 parameters")
+	})
+}
+
+func TestContainer_ResolveValue(t *testing.T) {
+	t.Run("interface type", func(t *testing.T) {
+		container := New()
+		db := &mockDatabase{}
+		require.NoError(t, container.Bind(func() Database { return db }))
+
+		instance, err := container.ResolveValue((*Database)(nil))
+		require.NoError(t, err)
+		assert.Same(t, db, instance)
+	})
+
+	t.Run("concrete type", func(t *testing.T) {
+		container := New()
+		require.NoError(t, container.Bind(func() *mockDatabase { return &mockDatabase{connected: true} }))
+		require.NoError(t, container.Bind(8080))
+
+		instance, err := container.ResolveValue((**mockDatabase)(nil))
+		require.NoError(t, err)
+		assert.True(t, instance.(*mockDatabase).connected)
+
+		port, err := container.ResolveValue(new(int))
+		require.NoError(t, err)
+		assert.Equal(t, 8080, port)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		container := New()
+
+		_, err := container.ResolveValue((*Database)(nil))
+		assert.ErrorIs(t, err, ErrBindingNotFound)
+		_, err = container.ResolveValue(Database(nil))
+		assert.ErrorIs(t, err, ErrNotAPointer)
+	})
+}
+
+func TestContainer_ResolveType(t *testing.T) {
+	t.Run("resolves the binding of a reflect.Type", func(t *testing.T) {
+		container := New()
+		db := &mockDatabase{}
+		require.NoError(t, container.Bind(func() Database { return db }))
+		require.NoError(t, container.BindNamed("replica", func() Database { return &mockDatabase{} }))
+
+		instance, err := container.ResolveType(reflect.TypeOf((*Database)(nil)).Elem(), "")
+		require.NoError(t, err)
+		assert.Same(t, db, instance)
+
+		var replica Database
+		require.NoError(t, container.ResolveNamed(&replica, "replica"))
+		instance, err = container.ResolveType(reflect.TypeOf((*Database)(nil)).Elem(), "replica")
+		require.NoError(t, err)
+		assert.Same(t, replica, instance)
+	})
+
+	t.Run("resolves a struct from a pointer binding", func(t *testing.T) {
+		container := New()
+		require.NoError(t, container.Bind(func() *mockDatabase { return &mockDatabase{connected: true} }))
+
+		instance, err := container.ResolveType(reflect.TypeOf(mockDatabase{}), "")
+		require.NoError(t, err)
+		assert.Equal(t, mockDatabase{connected: true}, instance)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		container := New()
+
+		_, err := container.ResolveType(reflect.TypeOf((*Database)(nil)).Elem(), "")
+		assert.ErrorIs(t, err, ErrBindingNotFound)
+		_, err = container.ResolveType(nil, "")
+		assert.EqualError(t, err, "container: cannot resolve a nil type")
+	})
+}
+
+func TestContainer_MustResolve(t *testing.T) {
+	t.Run("returns the instance", func(t *testing.T) {
+		container := New()
+		db := &mockDatabase{}
+		require.NoError(t, container.Bind(func() Database { return db }))
+
+		var resolved Database
+		assert.NotPanics(t, func() { container.MustResolve(&resolved) })
+		assert.Same(t, db, resolved)
+	})
+
+	t.Run("panics when resolution fails", func(t *testing.T) {
+		container := New()
+
+		var db Database
+		assert.PanicsWithValue(t, "di: failed to resolve di.Database: no binding found for type di.Database with name ''", func() {
+			container.MustResolve(&db)
+		})
+	})
+}
+
+type ReadOnlyDB interface {
+	Read(key string) string
+}
+
+type ReadWriteDB interface {
+	ReadOnlyDB
+	Write(key, value string)
+}
+
+type memoryDB map[string]string
+
+func (m memoryDB) Read(key string) string  { return m[key] }
+func (m memoryDB) Write(key, value string) { m[key] = value }
+
+func TestContainer_ResolveCoerce(t *testing.T) {
+	t.Run("broader binding into a narrower target", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func() ReadWriteDB {
+			return memoryDB{"greeting": "hello"}
+		})
+		require.NoError(t, err)
+
+		var reader ReadOnlyDB
+		require.NoError(t, container.ResolveCoerce(&reader, new(ReadWriteDB)))
+		assert.Equal(t, "hello", reader.Read("greeting"))
+
+		var writer ReadWriteDB
+		require.NoError(t, container.Resolve(&writer))
+		writer.Write("greeting", "hi")
+		assert.Equal(t, "hi", reader.Read("greeting"))
+	})
+
+	t.Run("source type is not assignable", func(t *testing.T) {
+		container := New()
+
+		var writer ReadWriteDB
+		err := container.ResolveCoerce(&writer, new(ReadOnlyDB))
+		assert.EqualError(t, err, "cannot coerce di.ReadOnlyDB to di.ReadWriteDB: not assignable")
+	})
+
+	t.Run("missing source binding", func(t *testing.T) {
+		container := New()
+
+		var reader ReadOnlyDB
+		assert.ErrorIs(t, container.ResolveCoerce(&reader, new(ReadWriteDB)), ErrBindingNotFound)
+	})
+}
+
 func TestContainer_SingletonBehavior(t *testing.T) {
 	t.Run("singleton instances are same by default", func(t *testing.T) {
 		container := New()
@@ -530,7 +913,7 @@ func TestContainer_Resolve(t *testing.T) {
 		err = container.Resolve(&userService)
 
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "failed resolving argument")
+		assert.EqualError(t, err, "failed resolving di.UserService -> di.Database: no binding found for di.Database")
 	})
 
 	t.Run("handle resolver function errors", func(t *testing.T) {
@@ -584,6 +967,33 @@ func TestContainer_TransientInstances(t *testing.T) {
 		// Should be the same instance by default (singleton)
 		assert.Same(t, db1, db2)
 	})
+
+	t.Run("repeated transient resolution injects every dependency", func(t *testing.T) {
+		container := New()
+		db := &mockDatabase{}
+		logger := &loggerImpl{}
+		require.NoError(t, container.Bind(func() Database { return db }))
+		require.NoError(t, container.Bind(func() Logger { return logger }))
+		require.NoError(t, container.BindTransient(func() UserService { return &userServiceImpl{} }))
+		err := container.BindTransient(func(db Database, userService UserService, logger Logger) OrderService {
+			return &orderServiceImpl{db: db, userService: userService, logger: logger}
+		})
+		require.NoError(t, err)
+
+		var previous OrderService
+		for i := 0; i < 3; i++ {
+			var orderService OrderService
+			require.NoError(t, container.Resolve(&orderService))
+			impl := orderService.(*orderServiceImpl)
+			assert.Same(t, db, impl.db)
+			assert.Same(t, logger, impl.logger)
+			assert.NotNil(t, impl.userService)
+			if previous != nil {
+				assert.NotSame(t, previous, orderService)
+			}
+			previous = orderService
+		}
+	})
 }
 
 func TestContainer_Clear(t *testing.T) {
@@ -604,6 +1014,33 @@ func TestContainer_Clear(t *testing.T) {
 	})
 }
 
+func TestContainer_Unbind(t *testing.T) {
+	t.Run("removes the binding", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func() Database {
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+		err = container.BindNamed("replica", func() Database {
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, container.Unbind(new(Database)))
+
+		var db Database
+		assert.ErrorIs(t, container.Resolve(&db), ErrBindingNotFound)
+		assert.NoError(t, container.ResolveNamed(&db, "replica"))
+	})
+
+	t.Run("missing binding", func(t *testing.T) {
+		container := New()
+
+		assert.ErrorIs(t, container.UnbindNamed(new(Database), "replica"), ErrBindingNotFound)
+		assert.ErrorIs(t, container.Unbind(nil), ErrNotAPointer)
+	})
+}
+
 func TestContainer_ThreadSafety(t *testing.T) {
 	t.Run("concurrent binding and resolution", func(t *testing.T) {
 		container := New()
@@ -636,4 +1073,573 @@ func TestContainer_ThreadSafety(t *testing.T) {
 			<-done
 		}
 	})
+
+	t.Run("binding new bindings while resolving", func(t *testing.T) {
+		container := New()
+
+		err := container.Bind(func() Database {
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		err = container.BindTransient(func(db Database) UserService {
+			return &userServiceImpl{db: db}
+		})
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func(i int) {
+				defer wg.Done()
+				err := container.BindNamed(fmt.Sprintf("db-%d", i), func() Database {
+					return &mockDatabase{}
+				})
+				assert.NoError(t, err)
+			}(i)
+			go func() {
+				defer wg.Done()
+				var userService UserService
+				assert.NoError(t, container.Resolve(&userService))
+				assert.NotNil(t, userService)
+			}()
+		}
+		wg.Wait()
+
+		named, err := container.ResolveAllNamed(new(Database))
+		require.NoError(t, err)
+		assert.Len(t, named, 11)
+	})
+
+	t.Run("factory binding into the same container", func(t *testing.T) {
+		container := New()
+
+		err := container.Bind(func() UserService {
+			err := container.Bind(func() Database {
+				return &mockDatabase{}
+			})
+			assert.NoError(t, err)
+			return &userServiceImpl{}
+		})
+		require.NoError(t, err)
+
+		var userService UserService
+		require.NoError(t, container.Resolve(&userService))
+
+		var db Database
+		assert.NoError(t, container.Resolve(&db))
+	})
+}
+
+type recordingLogger struct {
+	messages []string
+}
+
+func (r *recordingLogger) Log(message string) {
+	r.messages = append(r.messages, message)
+}
+
+func TestContainer_FailSafe(t *testing.T) {
+	t.Run("fallback used when factory errors", func(t *testing.T) {
+		container := New()
+		logger := &recordingLogger{}
+		container.SetLogger(logger)
+
+		fallback := &mockDatabase{connected: true}
+		err := container.Bind(func() (Database, error) {
+			return nil, errors.New("connection refused")
+		}, WithFailSafe(func() Database {
+			return fallback
+		}))
+		require.NoError(t, err)
+
+		var db Database
+		err = container.Resolve(&db)
+		require.NoError(t, err)
+		assert.Same(t, fallback, db)
+		assert.True(t, container.FallbackUsed(&db, ""))
+		require.Len(t, logger.messages, 1)
+		assert.Contains(t, logger.messages[0], "connection refused")
+	})
+
+	t.Run("fallback used when factory panics", func(t *testing.T) {
+		container := New()
+		container.SetLogger(&recordingLogger{})
+
+		err := container.BindNamed("primary", func() Database {
+			panic("boom")
+		}, WithFailSafe(func() Database {
+			return &mockDatabase{}
+		}))
+		require.NoError(t, err)
+
+		var db Database
+		err = container.ResolveNamed(&db, "primary")
+		require.NoError(t, err)
+		assert.NotNil(t, db)
+		assert.True(t, container.FallbackUsed(&db, "primary"))
+	})
+
+	t.Run("fallback not used when factory succeeds", func(t *testing.T) {
+		container := New()
+
+		err := container.Bind(func() Database {
+			return &mockDatabase{}
+		}, WithFailSafe(func() Database {
+			return &mockDatabase{connected: true}
+		}))
+		require.NoError(t, err)
+
+		var db Database
+		err = container.Resolve(&db)
+		require.NoError(t, err)
+		assert.False(t, db.(*mockDatabase).connected)
+		assert.False(t, container.FallbackUsed(&db, ""))
+	})
+
+	t.Run("error when fallback returns incompatible type", func(t *testing.T) {
+		container := New()
+
+		err := container.Bind(func() Database {
+			return &mockDatabase{}
+		}, WithFailSafe(func() Logger {
+			return &loggerImpl{}
+		}))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not assignable")
+	})
+}
+
+func TestContainer_LifetimeOverride(t *testing.T) {
+	t.Run("resolve transient bypasses singleton cache", func(t *testing.T) {
+		container := New()
+
+		err := container.Bind(func() Database {
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		var cached, fresh, cachedAgain Database
+		require.NoError(t, container.Resolve(&cached))
+		require.NoError(t, container.ResolveTransient(&fresh))
+		require.NoError(t, container.Resolve(&cachedAgain))
+
+		assert.NotSame(t, cached, fresh)
+		assert.Same(t, cached, cachedAgain)
+	})
+
+	t.Run("resolve singleton caches transient binding", func(t *testing.T) {
+		container := New()
+
+		err := container.BindTransient(func() Database {
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		var first, second, transient Database
+		require.NoError(t, container.ResolveSingleton(&first))
+		require.NoError(t, container.ResolveSingleton(&second))
+		require.NoError(t, container.Resolve(&transient))
+
+		assert.Same(t, first, second)
+		assert.NotSame(t, first, transient)
+	})
+
+	t.Run("error when binding not found", func(t *testing.T) {
+		container := New()
+
+		var db Database
+		assert.Error(t, container.ResolveTransient(&db))
+		assert.Error(t, container.ResolveSingleton(&db))
+	})
+}
+
+type valueDatabase struct {
+	dsn       string
+	connected bool
+}
+
+func (v *valueDatabase) Connect() error {
+	v.connected = true
+	return nil
+}
+
+func TestContainer_AutoAddr(t *testing.T) {
+	t.Run("resolve interface implemented on pointer", func(t *testing.T) {
+		container := New()
+
+		err := container.Bind(func() valueDatabase {
+			return valueDatabase{dsn: "postgres://db"}
+		}, WithAutoAddr())
+		require.NoError(t, err)
+
+		var db1, db2 Database
+		require.NoError(t, container.Resolve(&db1))
+		require.NoError(t, container.Resolve(&db2))
+
+		require.IsType(t, &valueDatabase{}, db1)
+		assert.Equal(t, "postgres://db", db1.(*valueDatabase).dsn)
+		assert.Same(t, db1, db2)
+	})
+
+	t.Run("resolve value type returns stored value", func(t *testing.T) {
+		container := New()
+
+		err := container.Bind(func() valueDatabase {
+			return valueDatabase{dsn: "postgres://db"}
+		}, WithAutoAddr())
+		require.NoError(t, err)
+
+		var db Database
+		require.NoError(t, container.Resolve(&db))
+		require.NoError(t, db.Connect())
+
+		var value valueDatabase
+		require.NoError(t, container.Resolve(&value))
+		assert.True(t, value.connected)
+	})
+
+	t.Run("inject interface into constructor", func(t *testing.T) {
+		container := New()
+
+		err := container.Bind(func() valueDatabase {
+			return valueDatabase{}
+		}, WithAutoAddr())
+		require.NoError(t, err)
+
+		err = container.Bind(func(db Database) UserService {
+			return &userServiceImpl{db: db}
+		})
+		require.NoError(t, err)
+
+		var userService UserService
+		require.NoError(t, container.Resolve(&userService))
+		assert.IsType(t, &valueDatabase{}, userService.(*userServiceImpl).db)
+	})
+
+	t.Run("error without option", func(t *testing.T) {
+		container := New()
+
+		err := container.Bind(func() valueDatabase {
+			return valueDatabase{}
+		})
+		require.NoError(t, err)
+
+		var db Database
+		err = container.Resolve(&db)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "no binding found")
+	})
+}
+
+func TestContainer_Precondition(t *testing.T) {
+	t.Run("resolve when precondition passes", func(t *testing.T) {
+		container := New()
+
+		err := container.Bind(func() Database {
+			return &mockDatabase{}
+		}, WithPrecondition(func() error {
+			return nil
+		}))
+		require.NoError(t, err)
+
+		var db Database
+		err = container.Resolve(&db)
+		require.NoError(t, err)
+		assert.NotNil(t, db)
+	})
+
+	t.Run("error when precondition fails", func(t *testing.T) {
+		container := New()
+		errMigrations := errors.New("migrations not applied")
+		constructed := false
+
+		err := container.Bind(func() Database {
+			constructed = true
+			return &mockDatabase{}
+		}, WithPrecondition(func() error {
+			return errMigrations
+		}))
+		require.NoError(t, err)
+
+		var db Database
+		err = container.Resolve(&db)
+		assert.ErrorIs(t, err, errMigrations)
+		assert.EqualError(t, err, "precondition failed for di.Database: migrations not applied")
+		assert.False(t, constructed)
+	})
+
+	t.Run("precondition is checked again after a failure", func(t *testing.T) {
+		container := New()
+		ready := false
+
+		err := container.Bind(func() Database {
+			return &mockDatabase{}
+		}, WithPrecondition(func() error {
+			if !ready {
+				return errors.New("not ready")
+			}
+			return nil
+		}))
+		require.NoError(t, err)
+
+		var db Database
+		assert.Error(t, container.Resolve(&db))
+
+		ready = true
+		assert.NoError(t, container.Resolve(&db))
+	})
+}
+
+type dbConfig struct {
+	dsn string
+}
+
+func TestContainer_WithValidate(t *testing.T) {
+	requireDSN := WithValidate(func(instance interface{}) error {
+		if instance.(*dbConfig).dsn == "" {
+			return errors.New("dsn is required")
+		}
+		return nil
+	})
+
+	t.Run("valid instance is resolved", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func() *dbConfig {
+			return &dbConfig{dsn: "postgres://localhost"}
+		}, requireDSN)
+		require.NoError(t, err)
+
+		var cfg *dbConfig
+		require.NoError(t, container.Resolve(&cfg))
+		assert.Equal(t, "postgres://localhost", cfg.dsn)
+	})
+
+	t.Run("invalid instance fails resolution and is not cached", func(t *testing.T) {
+		container := New()
+		dsn := ""
+		constructed := 0
+		err := container.Bind(func() *dbConfig {
+			constructed++
+			return &dbConfig{dsn: dsn}
+		}, requireDSN)
+		require.NoError(t, err)
+
+		var cfg *dbConfig
+		err = container.Resolve(&cfg)
+		assert.EqualError(t, err, "validation failed for *di.dbConfig: dsn is required")
+		assert.Nil(t, cfg)
+
+		dsn = "postgres://localhost"
+		require.NoError(t, container.Resolve(&cfg))
+		assert.Equal(t, "postgres://localhost", cfg.dsn)
+		assert.Equal(t, 2, constructed)
+	})
+}
+
+func TestContainer_SingletonConcurrentConstruction(t *testing.T) {
+	container := New()
+	var constructed atomic.Int32
+	start := make(chan struct{})
+
+	err := container.Bind(func() Database {
+		constructed.Add(1)
+		return &mockDatabase{}
+	})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	results := make([]Database, 50)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			assert.NoError(t, container.Resolve(&results[i]))
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), constructed.Load())
+	for _, db := range results {
+		assert.Same(t, results[0], db)
+	}
+}
+
+func TestContainer_MultipleResults(t *testing.T) {
+	t.Run("each result type is bound", func(t *testing.T) {
+		container := New()
+		calls := 0
+
+		err := container.Bind(func() (Database, Logger, error) {
+			calls++
+			return &mockDatabase{}, &loggerImpl{}, nil
+		})
+		require.NoError(t, err)
+
+		var logger Logger
+		require.NoError(t, container.Resolve(&logger))
+		assert.IsType(t, &loggerImpl{}, logger)
+
+		var db Database
+		require.NoError(t, container.Resolve(&db))
+		assert.IsType(t, &mockDatabase{}, db)
+
+		var again Database
+		require.NoError(t, container.Resolve(&again))
+		assert.Same(t, db, again)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("transient results invoke the factory each time", func(t *testing.T) {
+		container := New()
+		calls := 0
+
+		err := container.BindTransient(func() (Database, Logger) {
+			calls++
+			return &mockDatabase{}, &loggerImpl{}
+		})
+		require.NoError(t, err)
+
+		var db Database
+		require.NoError(t, container.Resolve(&db))
+		var logger Logger
+		require.NoError(t, container.Resolve(&logger))
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("factory error is returned for every result", func(t *testing.T) {
+		container := New()
+
+		err := container.Bind(func() (Database, Logger, error) {
+			return nil, nil, errors.New("connection refused")
+		})
+		require.NoError(t, err)
+
+		var db Database
+		assert.EqualError(t, container.Resolve(&db), "connection refused")
+		var logger Logger
+		assert.EqualError(t, container.Resolve(&logger), "connection refused")
+	})
+
+	t.Run("depending on a sibling result", func(t *testing.T) {
+		container := New()
+
+		err := container.Bind(func(logger Logger) (Database, UserService) {
+			return &mockDatabase{}, &userServiceImpl{}
+		})
+		require.NoError(t, err)
+		err = container.Bind(func(userService UserService) Logger {
+			return &loggerImpl{}
+		})
+		require.NoError(t, err)
+
+		var db Database
+		assert.ErrorIs(t, container.Resolve(&db), ErrCircularDependency)
+	})
+
+	t.Run("eager results are built by one invocation", func(t *testing.T) {
+		container := New()
+		calls := 0
+
+		err := container.Bind(func() (Database, Logger) {
+			calls++
+			return &mockDatabase{}, &loggerImpl{}
+		}, WithEager())
+		require.NoError(t, err)
+		assert.Equal(t, 1, calls)
+
+		var logger Logger
+		require.NoError(t, container.Resolve(&logger))
+		assert.Equal(t, 1, calls)
+	})
+}
+
+type Cache interface {
+	Get(key string) string
+}
+
+type memoryCache struct{}
+
+func (memoryCache) Get(key string) string { return "memory:" + key }
+
+type redisCache struct{}
+
+func (redisCache) Get(key string) string { return "redis:" + key }
+
+func TestContainer_WithCondition(t *testing.T) {
+	useMemory := func() bool { return os.Getenv("CACHE_DRIVER") == "memory" }
+	newContainer := func(t *testing.T) *Container {
+		container := New()
+		err := container.BindTransient(func() Cache {
+			return memoryCache{}
+		}, WithCondition(useMemory))
+		require.NoError(t, err)
+		err = container.BindTransient(func() Cache {
+			return redisCache{}
+		}, WithCondition(func() bool { return !useMemory() }))
+		require.NoError(t, err)
+		return container
+	}
+
+	t.Run("selects the candidate whose condition holds", func(t *testing.T) {
+		container := newContainer(t)
+
+		t.Setenv("CACHE_DRIVER", "memory")
+		var cache Cache
+		require.NoError(t, container.Resolve(&cache))
+		assert.Equal(t, "memory:key", cache.Get("key"))
+
+		t.Setenv("CACHE_DRIVER", "redis")
+		require.NoError(t, container.Resolve(&cache))
+		assert.Equal(t, "redis:key", cache.Get("key"))
+	})
+
+	t.Run("earliest registered candidate wins", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func() Cache {
+			return memoryCache{}
+		}, WithCondition(func() bool { return true }))
+		require.NoError(t, err)
+		err = container.Bind(func() Cache {
+			return redisCache{}
+		}, WithCondition(func() bool { return true }))
+		require.NoError(t, err)
+
+		var cache Cache
+		require.NoError(t, container.Resolve(&cache))
+		assert.IsType(t, memoryCache{}, cache)
+	})
+
+	t.Run("no candidate holds", func(t *testing.T) {
+		container := New()
+		called := false
+		err := container.Bind(func() Cache {
+			called = true
+			return memoryCache{}
+		}, WithCondition(func() bool { return false }), WithEager())
+		require.NoError(t, err)
+		assert.False(t, called)
+
+		var cache Cache
+		assert.ErrorIs(t, container.Resolve(&cache), ErrBindingNotFound)
+
+		var caches []Cache
+		require.NoError(t, container.ResolveAll(&caches))
+		assert.Empty(t, caches)
+	})
+
+	t.Run("unconditional binding replaces candidates", func(t *testing.T) {
+		container := newContainer(t)
+		err := container.Bind(func() Cache {
+			return redisCache{}
+		})
+		require.NoError(t, err)
+
+		t.Setenv("CACHE_DRIVER", "memory")
+		var cache Cache
+		require.NoError(t, container.Resolve(&cache))
+		assert.IsType(t, redisCache{}, cache)
+	})
 }
@@ -0,0 +1,161 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ctxKey struct{}
+
+type connection struct {
+	dsn string
+}
+
+func TestContainer_ResolveContext(t *testing.T) {
+	t.Run("factory receives the resolution context", func(t *testing.T) {
+		container := New()
+
+		err := container.Bind(func(ctx context.Context) *connection {
+			return &connection{dsn: ctx.Value(ctxKey{}).(string)}
+		})
+		require.NoError(t, err)
+
+		ctx := context.WithValue(context.Background(), ctxKey{}, "postgres://db")
+		var conn *connection
+		err = container.ResolveContext(ctx, &conn)
+		require.NoError(t, err)
+		assert.Equal(t, "postgres://db", conn.dsn)
+	})
+
+	t.Run("nested factories receive the same context", func(t *testing.T) {
+		container := New()
+
+		err := container.Bind(func(ctx context.Context) (Database, error) {
+			if ctx.Value(ctxKey{}) == nil {
+				return nil, errors.New("missing context value")
+			}
+			return &mockDatabase{}, nil
+		})
+		require.NoError(t, err)
+
+		err = container.Bind(func(db Database) UserService {
+			return &userServiceImpl{db: db}
+		})
+		require.NoError(t, err)
+
+		ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+		var userService UserService
+		err = container.ResolveContext(ctx, &userService)
+		require.NoError(t, err)
+		assert.NotNil(t, userService)
+	})
+
+	t.Run("canceled context aborts resolution", func(t *testing.T) {
+		container := New()
+		called := false
+
+		err := container.Bind(func() Database {
+			called = true
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var db Database
+		err = container.ResolveContext(ctx, &db)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.False(t, called)
+	})
+
+	t.Run("context canceled during resolution stops later factories", func(t *testing.T) {
+		container := New()
+		ctx, cancel := context.WithCancel(context.Background())
+
+		err := container.Bind(func() Database {
+			cancel()
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		err = container.Bind(func() Logger {
+			return &loggerImpl{}
+		})
+		require.NoError(t, err)
+
+		err = container.Bind(func(db Database, logger Logger) UserService {
+			return &userServiceImpl{db: db}
+		})
+		require.NoError(t, err)
+
+		var userService UserService
+		err = container.ResolveContext(ctx, &userService)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("resolve uses background context", func(t *testing.T) {
+		container := New()
+
+		err := container.Bind(func(ctx context.Context) *connection {
+			return &connection{dsn: "default"}
+		})
+		require.NoError(t, err)
+
+		var conn *connection
+		err = container.Resolve(&conn)
+		require.NoError(t, err)
+		assert.Equal(t, "default", conn.dsn)
+	})
+}
+
+func TestContainer_WithKeyFunc(t *testing.T) {
+	newContainer := func(t *testing.T) (*Container, *int) {
+		container := New()
+		constructed := 0
+
+		err := container.Bind(func(ctx context.Context) *connection {
+			constructed++
+			return &connection{dsn: ctx.Value(ctxKey{}).(string)}
+		}, WithKeyFunc(func(args ...interface{}) string {
+			return args[0].(context.Context).Value(ctxKey{}).(string)
+		}))
+		require.NoError(t, err)
+
+		return container, &constructed
+	}
+
+	resolve := func(t *testing.T, container *Container, database string) *connection {
+		var conn *connection
+		ctx := context.WithValue(context.Background(), ctxKey{}, database)
+		require.NoError(t, container.ResolveContext(ctx, &conn))
+		return conn
+	}
+
+	t.Run("same key shares an instance", func(t *testing.T) {
+		container, constructed := newContainer(t)
+
+		first := resolve(t, container, "orders")
+		second := resolve(t, container, "orders")
+
+		assert.Same(t, first, second)
+		assert.Equal(t, 1, *constructed)
+	})
+
+	t.Run("different key yields a new instance", func(t *testing.T) {
+		container, constructed := newContainer(t)
+
+		orders := resolve(t, container, "orders")
+		users := resolve(t, container, "users")
+
+		assert.NotSame(t, orders, users)
+		assert.Equal(t, "orders", orders.dsn)
+		assert.Equal(t, "users", users.dsn)
+		assert.Equal(t, 2, *constructed)
+		assert.Same(t, orders, resolve(t, container, "orders"))
+	})
+}
@@ -0,0 +1,127 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// DecorateOption represents a configuration option for a decorator
+type DecorateOption func(*decorator)
+
+// WithDecoratorPriority sets the order in which a decorator is applied.
+// Decorators with lower priorities are applied first and therefore sit innermost;
+// decorators with equal priorities are applied in registration order. The default priority is 0.
+func WithDecoratorPriority(priority int) DecorateOption {
+	return func(d *decorator) {
+		d.priority = priority
+	}
+}
+
+// decorator wraps instances of a type after they are constructed.
+type decorator struct {
+	function reflect.Value
+	priority int
+}
+
+// Decorate registers a decorator for the type the target points to.
+// The decorator must be a function of the form func(T, deps...) T or func(T, deps...) (T, error);
+// additional parameters are resolved from the container. When T is resolved, the base instance is
+// built first and then passed through every decorator in priority order, see WithDecoratorPriority.
+// Singletons are decorated once, before they are cached.
+func (c *Container) Decorate(target interface{}, decorator interface{}, options ...DecorateOption) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	targetType := reflect.TypeOf(target)
+	if targetType == nil || targetType.Kind() != reflect.Ptr {
+		return ErrNotAPointer
+	}
+	decoratedType := targetType.Elem()
+
+	if err := validateDecorator(decoratedType, reflect.TypeOf(decorator)); err != nil {
+		return err
+	}
+
+	d := newDecorator(decorator)
+	for _, option := range options {
+		option(d)
+	}
+
+	// Cap the current slice so append copies it; resolutions in flight may still be iterating it
+	current := c.decorators[decoratedType]
+	decorators := append(current[:len(current):len(current)], d)
+	sortDecorators(decorators)
+	c.decorators[decoratedType] = decorators
+	return nil
+}
+
+// sortDecorators orders decorators by priority, keeping the registration order of equal priorities.
+func sortDecorators(decorators []*decorator) {
+	sort.SliceStable(decorators, func(i, j int) bool {
+		return decorators[i].priority < decorators[j].priority
+	})
+}
+
+func newDecorator(function interface{}) *decorator {
+	return &decorator{function: reflect.ValueOf(function)}
+}
+
+func validateDecorator(decoratedType, funcType reflect.Type) error {
+	if funcType == nil || funcType.Kind() != reflect.Func {
+		return fmt.Errorf("container: the decorator %w", ErrNotAFunction)
+	}
+
+	if funcType.NumIn() == 0 || funcType.In(0) != decoratedType {
+		return fmt.Errorf("decorator must accept %s as its first parameter", decoratedType)
+	}
+
+	retCount := funcType.NumOut()
+	if retCount == 0 || retCount > 2 || !funcType.Out(0).AssignableTo(decoratedType) {
+		return fmt.Errorf("decorator must return %s and an optional error", decoratedType)
+	}
+	if retCount == 2 && funcType.Out(1) != errorType {
+		return fmt.Errorf("decorator must return %s and an optional error", decoratedType)
+	}
+
+	return nil
+}
+
+// decorate passes the instance through every decorator registered for the type.
+func (c *Container) decorate(r *resolution, t reflect.Type, instance any) (any, error) {
+	c.lock.RLock()
+	decorators := c.decorators[t]
+	c.lock.RUnlock()
+
+	for _, d := range decorators {
+		funcType := d.function.Type()
+		arguments := make([]reflect.Value, funcType.NumIn())
+		arguments[0] = valueOf(instance, t)
+
+		for i := 1; i < funcType.NumIn(); i++ {
+			argument, err := c.resolveArgument(r, funcType.In(i))
+			if err != nil {
+				return nil, err
+			}
+			arguments[i] = argument
+		}
+
+		decorated, err := callRecovered("decorating", t, d.function, arguments)
+		if err != nil {
+			return nil, err
+		}
+		instance = decorated[0].Interface()
+	}
+
+	return instance, nil
+}
+
+// valueOf returns the reflected value of instance, using the zero value of t for nil instances.
+func valueOf(instance any, t reflect.Type) reflect.Value {
+	if instance == nil {
+		return reflect.Zero(t)
+	}
+	return reflect.ValueOf(instance)
+}
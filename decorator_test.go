@@ -0,0 +1,210 @@
+package di
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type prefixLogger struct {
+	prefix string
+	next   Logger
+}
+
+func (p *prefixLogger) Log(message string) {
+	p.next.Log(p.prefix + message)
+}
+
+func TestContainer_Decorate(t *testing.T) {
+	t.Run("decorator wraps the constructed instance", func(t *testing.T) {
+		container := New()
+		var trace []string
+		base := &loggerImpl{}
+
+		err := container.Bind(func() Logger {
+			trace = append(trace, "factory")
+			return base
+		})
+		require.NoError(t, err)
+
+		err = container.Decorate((*Logger)(nil), func(logger Logger) Logger {
+			trace = append(trace, "decorator")
+			return &prefixLogger{prefix: "[app] ", next: logger}
+		})
+		require.NoError(t, err)
+
+		var logger Logger
+		err = container.Resolve(&logger)
+		require.NoError(t, err)
+
+		logger.Log("started")
+		assert.Equal(t, []string{"[app] started"}, base.messages)
+		assert.Equal(t, []string{"factory", "decorator"}, trace)
+	})
+
+	t.Run("decorators compose in registration order", func(t *testing.T) {
+		container := New()
+		base := &loggerImpl{}
+
+		err := container.Bind(func() Logger {
+			return base
+		})
+		require.NoError(t, err)
+
+		for _, prefix := range []string{"inner:", "outer:"} {
+			prefix := prefix
+			err = container.Decorate((*Logger)(nil), func(logger Logger) Logger {
+				return &prefixLogger{prefix: prefix, next: logger}
+			})
+			require.NoError(t, err)
+		}
+
+		var logger Logger
+		err = container.Resolve(&logger)
+		require.NoError(t, err)
+
+		logger.Log("msg")
+		assert.Equal(t, []string{"inner:outer:msg"}, base.messages)
+	})
+
+	t.Run("decorators apply in priority order", func(t *testing.T) {
+		container := New()
+		var trace []string
+
+		err := container.Bind(func() Logger {
+			return &loggerImpl{}
+		})
+		require.NoError(t, err)
+
+		register := func(name string, priority int) {
+			err := container.Decorate((*Logger)(nil), func(logger Logger) Logger {
+				trace = append(trace, name)
+				return logger
+			}, WithDecoratorPriority(priority))
+			require.NoError(t, err)
+		}
+		register("logging", 10)
+		register("metrics", -10)
+		register("tracing", 0)
+
+		var logger Logger
+		err = container.Resolve(&logger)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"metrics", "tracing", "logging"}, trace)
+	})
+
+	t.Run("equal priorities keep registration order", func(t *testing.T) {
+		container := New()
+		var trace []string
+
+		err := container.Bind(func() Logger {
+			return &loggerImpl{}
+		})
+		require.NoError(t, err)
+
+		for _, name := range []string{"first", "second", "third"} {
+			name := name
+			err := container.Decorate((*Logger)(nil), func(logger Logger) Logger {
+				trace = append(trace, name)
+				return logger
+			}, WithDecoratorPriority(1))
+			require.NoError(t, err)
+		}
+
+		var logger Logger
+		err = container.Resolve(&logger)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"first", "second", "third"}, trace)
+	})
+
+	t.Run("decorator with dependencies", func(t *testing.T) {
+		container := New()
+
+		err := container.Bind(func() Logger {
+			return &loggerImpl{}
+		})
+		require.NoError(t, err)
+
+		err = container.Bind(func() Database {
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		var received Logger
+		err = container.Decorate((*Database)(nil), func(db Database, logger Logger) Database {
+			received = logger
+			return db
+		})
+		require.NoError(t, err)
+
+		var db Database
+		err = container.Resolve(&db)
+		require.NoError(t, err)
+		assert.NotNil(t, received)
+	})
+
+	t.Run("singleton is decorated once", func(t *testing.T) {
+		container := New()
+		calls := 0
+
+		err := container.Bind(func() Database {
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		err = container.Decorate((*Database)(nil), func(db Database) Database {
+			calls++
+			return db
+		})
+		require.NoError(t, err)
+
+		var db1, db2 Database
+		require.NoError(t, container.Resolve(&db1))
+		require.NoError(t, container.Resolve(&db2))
+		assert.Same(t, db1, db2)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("decorator errors are returned", func(t *testing.T) {
+		container := New()
+
+		err := container.Bind(func() Database {
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		err = container.Decorate((*Database)(nil), func(db Database) (Database, error) {
+			return nil, errors.New("decoration failed")
+		})
+		require.NoError(t, err)
+
+		var db Database
+		err = container.Resolve(&db)
+		assert.EqualError(t, err, "decoration failed")
+	})
+
+	t.Run("error when decorator signature is invalid", func(t *testing.T) {
+		container := New()
+
+		err := container.Decorate((*Database)(nil), "not a function")
+		assert.Error(t, err)
+
+		err = container.Decorate((*Database)(nil), func(logger Logger) Database {
+			return nil
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "first parameter")
+
+		err = container.Decorate((*Database)(nil), func(db Database) Logger {
+			return nil
+		})
+		assert.Error(t, err)
+
+		err = container.Decorate(nil, func(db Database) Database {
+			return db
+		})
+		assert.Error(t, err)
+	})
+}
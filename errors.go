@@ -0,0 +1,23 @@
+package di
+
+import "errors"
+
+var (
+	// ErrBindingNotFound is returned when no binding exists for a requested type and name.
+	ErrBindingNotFound = errors.New("no binding found")
+
+	// ErrNotAPointer is returned when a resolution target is not a pointer.
+	ErrNotAPointer = errors.New("target must be a pointer")
+
+	// ErrNilPointer is returned when a resolution target is a nil pointer, which has nowhere to store the instance.
+	ErrNilPointer = errors.New("target pointer must not be nil")
+
+	// ErrNotAFunction is returned when a resolver, fallback or decorator is not a function.
+	ErrNotAFunction = errors.New("must be a function")
+
+	// ErrCircularDependency is returned when constructing an instance requires the instance itself.
+	ErrCircularDependency = errors.New("circular dependency")
+
+	// ErrTimeout is returned when a factory registered with WithTimeout does not complete in time.
+	ErrTimeout = errors.New("factory timed out")
+)
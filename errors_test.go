@@ -0,0 +1,300 @@
+package di
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer_SentinelErrors(t *testing.T) {
+	t.Run("binding not found", func(t *testing.T) {
+		container := New()
+
+		var db Database
+		err := container.ResolveNamed(&db, "primary")
+		assert.ErrorIs(t, err, ErrBindingNotFound)
+		assert.Contains(t, err.Error(), "di.Database")
+		assert.Contains(t, err.Error(), "primary")
+	})
+
+	t.Run("dependency not found", func(t *testing.T) {
+		container := New()
+
+		err := container.Bind(func(db Database) UserService {
+			return &userServiceImpl{db: db}
+		})
+		require.NoError(t, err)
+
+		var userService UserService
+		err = container.Resolve(&userService)
+		assert.ErrorIs(t, err, ErrBindingNotFound)
+		assert.Contains(t, err.Error(), "di.Database")
+	})
+
+	t.Run("not a pointer", func(t *testing.T) {
+		container := New()
+
+		var db Database
+		assert.ErrorIs(t, container.Resolve(db), ErrNotAPointer)
+
+		var dbs []Database
+		assert.ErrorIs(t, container.ResolveAll(dbs), ErrNotAPointer)
+	})
+
+	t.Run("nil pointer", func(t *testing.T) {
+		container := New()
+		require.NoError(t, container.Bind(func() Database { return &mockDatabase{} }))
+
+		var db *Database
+		assert.NotPanics(t, func() {
+			err := container.Resolve(db)
+			assert.ErrorIs(t, err, ErrNilPointer)
+			assert.EqualError(t, err, "target pointer must not be nil")
+		})
+		assert.ErrorIs(t, container.ResolveFirst(db, "primary"), ErrNilPointer)
+		assert.ErrorIs(t, container.ResolveCoerce(db, new(Database)), ErrNilPointer)
+
+		var dbs *[]Database
+		assert.ErrorIs(t, container.ResolveAll(dbs), ErrNilPointer)
+	})
+
+	t.Run("not a function", func(t *testing.T) {
+		container := New()
+
+		assert.ErrorIs(t, container.Bind(nil), ErrNotAFunction)
+		assert.ErrorIs(t, container.Decorate((*Database)(nil), 42), ErrNotAFunction)
+	})
+
+	t.Run("circular dependency", func(t *testing.T) {
+		container := New()
+
+		err := container.Bind(func(logger Logger) Database {
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		err = container.Bind(func(db Database) Logger {
+			return &loggerImpl{}
+		})
+		require.NoError(t, err)
+
+		var db Database
+		err = container.Resolve(&db)
+		assert.ErrorIs(t, err, ErrCircularDependency)
+		assert.Contains(t, err.Error(), "di.Database")
+	})
+
+	t.Run("circular dependency between transients", func(t *testing.T) {
+		container := New()
+
+		err := container.BindTransient(func(logger Logger) Database {
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		err = container.BindTransient(func(db Database) Logger {
+			return &loggerImpl{}
+		})
+		require.NoError(t, err)
+
+		var logger Logger
+		err = container.Resolve(&logger)
+		assert.True(t, errors.Is(err, ErrCircularDependency))
+	})
+}
+
+func TestContainer_ResolutionPath(t *testing.T) {
+	container := New()
+
+	err := container.Bind(func(orderService OrderService) UserService {
+		return &userServiceImpl{}
+	})
+	require.NoError(t, err)
+
+	err = container.Bind(func(db Database) OrderService {
+		return &orderServiceImpl{db: db}
+	})
+	require.NoError(t, err)
+
+	var userService UserService
+	err = container.Resolve(&userService)
+	assert.ErrorIs(t, err, ErrBindingNotFound)
+	assert.EqualError(t, err, "failed resolving di.UserService -> di.OrderService -> di.Database: no binding found for di.Database")
+
+	// The path is relative to the top-level resolution.
+	var orderService OrderService
+	err = container.Resolve(&orderService)
+	assert.EqualError(t, err, "failed resolving di.OrderService -> di.Database: no binding found for di.Database")
+}
+
+func TestContainer_AssignTarget(t *testing.T) {
+	t.Run("error when the instance is not assignable to the target", func(t *testing.T) {
+		container := New()
+		require.NoError(t, container.Bind(func() Database { return &mockDatabase{} }))
+		require.NoError(t, container.Bind(func() UserService { return &userServiceImpl{} }))
+
+		// Simulate a binding whose factory no longer produces the type it is registered under
+		databaseType := reflect.TypeOf((*Database)(nil)).Elem()
+		userServiceType := reflect.TypeOf((*UserService)(nil)).Elem()
+		container.bindings[databaseType][""] = container.bindings[userServiceType][""]
+
+		var db Database
+		assert.NotPanics(t, func() {
+			err := container.Resolve(&db)
+			assert.EqualError(t, err, "cannot assign instance of type *di.userServiceImpl to target of type di.Database")
+		})
+	})
+
+	t.Run("nil instance resolves to the zero value", func(t *testing.T) {
+		container := New()
+		require.NoError(t, container.Bind(func() Database { return nil }))
+
+		db := Database(&mockDatabase{})
+		require.NoError(t, container.Resolve(&db))
+		assert.Nil(t, db)
+	})
+}
+
+func TestContainer_FactoryPanic(t *testing.T) {
+	t.Run("panic is returned as an error", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func() UserService {
+			panic("boom")
+		})
+		require.NoError(t, err)
+
+		var userService UserService
+		assert.NotPanics(t, func() {
+			err = container.Resolve(&userService)
+		})
+		assert.EqualError(t, err, "panic while constructing di.UserService: boom")
+		assert.Nil(t, userService)
+	})
+
+	t.Run("panic in a dependency fails the dependent", func(t *testing.T) {
+		container := New()
+		require.NoError(t, container.Bind(func() Database {
+			panic("connection lost")
+		}))
+		require.NoError(t, container.Bind(func(db Database) UserService {
+			return &userServiceImpl{}
+		}))
+
+		var userService UserService
+		err := container.Resolve(&userService)
+		assert.ErrorContains(t, err, "panic while constructing di.Database: connection lost")
+	})
+
+	t.Run("panic in the fallback factory", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func() (Database, error) {
+			return nil, errors.New("primary down")
+		}, WithFailSafe(func() Database {
+			panic("replica down")
+		}))
+		require.NoError(t, err)
+
+		var db Database
+		assert.NotPanics(t, func() {
+			err = container.Resolve(&db)
+		})
+		assert.EqualError(t, err, "panic while constructing di.Database: replica down")
+	})
+
+	t.Run("panic in a decorator", func(t *testing.T) {
+		container := New()
+		require.NoError(t, container.Bind(func() Database { return &mockDatabase{} }))
+		err := container.Decorate((*Database)(nil), func(db Database) Database {
+			panic("boom")
+		})
+		require.NoError(t, err)
+
+		var db Database
+		assert.NotPanics(t, func() {
+			err = container.Resolve(&db)
+		})
+		assert.EqualError(t, err, "panic while decorating di.Database: boom")
+	})
+
+	t.Run("panic in a spread factory", func(t *testing.T) {
+		container := New()
+		var err error
+		assert.NotPanics(t, func() {
+			err = container.Bind(func() []Database {
+				panic("boom")
+			}, WithSpread())
+		})
+		assert.EqualError(t, err, "panic while constructing []di.Database: boom")
+	})
+
+	t.Run("panic in a keyed factory", func(t *testing.T) {
+		container := New()
+		err := BindKeyed(container, func(tenant string) Database {
+			panic("boom")
+		})
+		require.NoError(t, err)
+
+		assert.NotPanics(t, func() {
+			_, err = ResolveKeyed[string, Database](container, "acme")
+		})
+		assert.EqualError(t, err, "panic while constructing di.Database for key acme: boom")
+
+		// The panic is not cached, the factory runs again for the key
+		_, err = ResolveKeyed[string, Database](container, "acme")
+		assert.Error(t, err)
+	})
+}
+
+func TestContainer_PanicOnMissing(t *testing.T) {
+	t.Run("missing dependency panics with the path", func(t *testing.T) {
+		container := New()
+		container.SetPanicOnMissing(true)
+
+		err := container.Bind(func(orderService OrderService) UserService {
+			return &userServiceImpl{}
+		})
+		require.NoError(t, err)
+
+		err = container.Bind(func(db Database) OrderService {
+			return &orderServiceImpl{db: db}
+		})
+		require.NoError(t, err)
+
+		var recovered interface{}
+		func() {
+			defer func() {
+				recovered = recover()
+			}()
+			var userService UserService
+			_ = container.Resolve(&userService)
+		}()
+
+		require.NotNil(t, recovered)
+		panicErr, ok := recovered.(error)
+		require.True(t, ok)
+		assert.ErrorIs(t, panicErr, ErrBindingNotFound)
+		assert.Contains(t, panicErr.Error(), "di.UserService -> di.OrderService -> di.Database")
+	})
+
+	t.Run("missing target panics", func(t *testing.T) {
+		container := New()
+		container.SetPanicOnMissing(true)
+
+		var db Database
+		assert.Panics(t, func() {
+			_ = container.Resolve(&db)
+		})
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		container := New()
+
+		var db Database
+		assert.NotPanics(t, func() {
+			assert.Error(t, container.Resolve(&db))
+		})
+	})
+}
@@ -3,7 +3,7 @@ package main
 import (
 	"fmt"
 
-	"github.com/ahn84/yadi"
+	yadi "github.com/ahn84/yadi"
 )
 
 type Greeter interface {
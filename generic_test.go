@@ -0,0 +1,85 @@
+package di
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Repository[T any] interface {
+	Save(entity T) error
+	All() []T
+}
+
+type memoryRepository[T any] struct {
+	entities []T
+}
+
+func (r *memoryRepository[T]) Save(entity T) error {
+	r.entities = append(r.entities, entity)
+	return nil
+}
+
+func (r *memoryRepository[T]) All() []T {
+	return r.entities
+}
+
+type User struct {
+	Name string
+}
+
+type Order struct {
+	ID int
+}
+
+func TestGenericTypes(t *testing.T) {
+	t.Run("instantiations are separate bindings", func(t *testing.T) {
+		container := New()
+		require.NoError(t, container.Bind(func() Repository[User] { return &memoryRepository[User]{} }))
+		require.NoError(t, container.Bind(func() Repository[Order] { return &memoryRepository[Order]{} }))
+
+		var users Repository[User]
+		require.NoError(t, container.Resolve(&users))
+		var orders Repository[Order]
+		require.NoError(t, container.Resolve(&orders))
+
+		require.NoError(t, users.Save(User{Name: "alice"}))
+		require.NoError(t, orders.Save(Order{ID: 1}))
+		assert.Equal(t, []User{{Name: "alice"}}, users.All())
+		assert.Equal(t, []Order{{ID: 1}}, orders.All())
+
+		var again Repository[User]
+		require.NoError(t, container.Resolve(&again))
+		assert.Same(t, users, again)
+		assert.Len(t, container.Bindings(), 2)
+	})
+
+	t.Run("instantiations are injected by type", func(t *testing.T) {
+		container := New()
+		require.NoError(t, container.Bind(func() Repository[User] { return &memoryRepository[User]{} }))
+		err := container.Bind(func(users Repository[User]) Repository[Order] {
+			return &memoryRepository[Order]{}
+		})
+		require.NoError(t, err)
+		require.NoError(t, container.Validate())
+
+		orders, err := Get[Repository[Order]](container)
+		require.NoError(t, err)
+		assert.NotNil(t, orders)
+	})
+
+	t.Run("missing instantiation is not satisfied by another", func(t *testing.T) {
+		container := New()
+		require.NoError(t, container.Bind(func() Repository[User] { return &memoryRepository[User]{} }))
+
+		var orders Repository[Order]
+		assert.ErrorIs(t, container.Resolve(&orders), ErrBindingNotFound)
+	})
+
+	t.Run("factory cannot depend on its own instantiation", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func(users Repository[User]) Repository[User] { return users })
+		assert.EqualError(t, err, "can't depend on return type")
+	})
+}
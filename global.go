@@ -1,47 +1,358 @@
 package di
 
-var global = New()
+import (
+	"context"
+	"reflect"
+	"sync/atomic"
+)
+
+// global holds the container behind the package-level functions.
+var global atomic.Pointer[Container]
+
+func init() {
+	global.Store(New())
+}
+
+// Global returns the container the package-level functions operate on.
+func Global() *Container {
+	return global.Load()
+}
+
+// SetGlobal atomically replaces the container the package-level functions operate on, e.g. to give a test
+// an isolated container and restore the previous one afterwards. A nil container installs a new empty one.
+func SetGlobal(c *Container) {
+	if c == nil {
+		c = New()
+	}
+	global.Store(c)
+}
 
 // Bind registers a factory function in the global container.
 // The resolver function's parameters will be automatically resolved when the return type is requested.
 func Bind(resolver interface{}, options ...BindOption) error {
-	return global.Bind(resolver, options...)
+	return Global().Bind(resolver, options...)
 }
 
 // Resolve returns an instance from the global container by setting the value of the provided pointer.
 // The target must be a pointer to the type you want to resolve.
-func Resolve(target interface{}) error {
-	return global.Resolve(target)
+func Resolve(target interface{}, options ...ResolveOption) error {
+	return Global().Resolve(target, options...)
+}
+
+// MustResolve returns the instance of T from the global container and panics if the resolution fails.
+func MustResolve[T any]() T {
+	var instance T
+	Global().MustResolve(&instance)
+	return instance
 }
 
 // ResolveNamed returns a named instance from the global container by setting the value of the provided pointer.
 // The target must be a pointer to the type you want to resolve.
-func ResolveNamed(target interface{}, name string) error {
-	return global.ResolveNamed(target, name)
+func ResolveNamed(target interface{}, name string, options ...ResolveOption) error {
+	return Global().ResolveNamed(target, name, options...)
 }
 
 // ResolveAll returns all instances of a given type from the global container.
 // The target must be a pointer to a slice of the type you want to resolve.
 func ResolveAll(target interface{}) error {
-	return global.ResolveAll(target)
+	return Global().ResolveAll(target)
 }
 
 // BindTransient is a convenience method for binding a transient instance in the global container.
 func BindTransient(resolver interface{}, options ...BindOption) error {
-	return global.BindTransient(resolver, options...)
+	return Global().BindTransient(resolver, options...)
 }
 
 // BindNamed is a convenience method for binding with a name in the global container.
 func BindNamed(name string, resolver interface{}, options ...BindOption) error {
-	return global.BindNamed(name, resolver, options...)
+	return Global().BindNamed(name, resolver, options...)
 }
 
 // BindNamedTransient is a convenience method for binding a named transient instance in the global container.
 func BindNamedTransient(name string, resolver interface{}, options ...BindOption) error {
-	return global.BindNamedTransient(name, resolver, options...)
+	return Global().BindNamedTransient(name, resolver, options...)
 }
 
 // Clear removes all bindings from the global container.
 func Clear() {
-	global.Clear()
+	Global().Clear()
+}
+
+// ResolveContext is like Resolve but honors cancellation of ctx, using the global container.
+func ResolveContext(ctx context.Context, target interface{}) error {
+	return Global().ResolveContext(ctx, target)
+}
+
+// ResolveFirst resolves the first of the names that is bound for the target type in the global container.
+func ResolveFirst(target interface{}, names ...string) error {
+	return Global().ResolveFirst(target, names...)
+}
+
+// ResolveTransient constructs a new instance for the target from the global container,
+// even if the type is bound as a singleton.
+func ResolveTransient(target interface{}) error {
+	return Global().ResolveTransient(target)
+}
+
+// ResolveSingleton returns a cached instance for the target from the global container,
+// even if the type is bound as transient.
+func ResolveSingleton(target interface{}) error {
+	return Global().ResolveSingleton(target)
+}
+
+// ResolveByTag returns every instance carrying the tag from the global container.
+// The target must be a pointer to a slice of the type you want to resolve.
+func ResolveByTag(tag string, target interface{}) error {
+	return Global().ResolveByTag(tag, target)
+}
+
+// ResolveAllNamed returns every instance of the type the target points to from the global container, keyed by binding name.
+func ResolveAllNamed(target interface{}) (map[string]interface{}, error) {
+	return Global().ResolveAllNamed(target)
+}
+
+// ResolveWhere resolves every binding of the global container whose metadata matches the predicate.
+func ResolveWhere(pred func(BindingInfo) bool) ([]interface{}, error) {
+	return Global().ResolveWhere(pred)
+}
+
+// ResolveWithLogger resolves the target from the global container, injecting logger into every factory that takes a Logger.
+func ResolveWithLogger(target interface{}, logger Logger) error {
+	return Global().ResolveWithLogger(target, logger)
+}
+
+// FallbackUsed reports whether the global binding for the target type and name was constructed by its fail-safe fallback.
+func FallbackUsed(target interface{}, name string) bool {
+	return Global().FallbackUsed(target, name)
+}
+
+// ImplementationName returns the name of the concrete type produced by the global binding for the target type and name.
+func ImplementationName(target interface{}, name string) (string, error) {
+	return Global().ImplementationName(target, name)
+}
+
+// Decorate registers a decorator for the type the target points to in the global container.
+func Decorate(target interface{}, decorator interface{}, options ...DecorateOption) error {
+	return Global().Decorate(target, decorator, options...)
+}
+
+// Alias makes an interface type resolve through the binding of a concrete type in the global container.
+func Alias(interfaceTarget, concreteTarget interface{}) error {
+	return Global().Alias(interfaceTarget, concreteTarget)
+}
+
+// Unbind removes the default binding for the type the target points to from the global container.
+func Unbind(target interface{}) error {
+	return Global().Unbind(target)
+}
+
+// UnbindNamed removes the named binding for the type the target points to from the global container.
+func UnbindNamed(target interface{}, name string) error {
+	return Global().UnbindNamed(target, name)
+}
+
+// ResetSingleton discards the cached instance of the global default binding for the type the target points to.
+func ResetSingleton(target interface{}) error {
+	return Global().ResetSingleton(target)
+}
+
+// ResetSingletonNamed discards the cached instance of the named global binding for the type the target points to.
+func ResetSingletonNamed(target interface{}, name string) error {
+	return Global().ResetSingletonNamed(target, name)
+}
+
+// Transaction applies the changes fn makes to a staging copy of the global container if fn returns nil.
+func Transaction(fn func(tx *Container) error) error {
+	return Global().Transaction(fn)
+}
+
+// Validate checks that every dependency of the global container's bindings can be satisfied.
+func Validate() error {
+	return Global().Validate()
+}
+
+// SetDeferEager controls whether eager bindings of the global container are instantiated by Start instead of Bind.
+func SetDeferEager(deferEager bool) {
+	Global().SetDeferEager(deferEager)
+}
+
+// Start instantiates every deferred eager binding of the global container and starts its Startable singletons.
+func Start(ctx context.Context) error {
+	return Global().Start(ctx)
+}
+
+// Stop stops the Startable singletons of the global container started by Start.
+func Stop(ctx context.Context) error {
+	return Global().Stop(ctx)
+}
+
+// SetActiveProfiles replaces the set of active profiles of the global container.
+func SetActiveProfiles(profiles ...string) {
+	Global().SetActiveProfiles(profiles...)
+}
+
+// SetPanicOnMissing makes resolution from the global container panic when a binding is missing.
+func SetPanicOnMissing(panicOnMissing bool) {
+	Global().SetPanicOnMissing(panicOnMissing)
+}
+
+// SetLogger replaces the logger used for diagnostics of the global container.
+func SetLogger(logger Logger) {
+	Global().SetLogger(logger)
+}
+
+// RecordTimings enables or disables recording construction timings in the global container.
+func RecordTimings(enabled bool) {
+	Global().RecordTimings(enabled)
+}
+
+// ExportTimings returns the construction timings recorded by the global container.
+func ExportTimings() []TimingEntry {
+	return Global().ExportTimings()
+}
+
+// BindCallback registers a callback under the event name in the global container.
+func BindCallback(name string, callback interface{}) error {
+	return Global().BindCallback(name, callback)
+}
+
+// Trigger calls every callback registered under the event name in the global container.
+func Trigger(name string, args ...interface{}) error {
+	return Global().Trigger(name, args...)
+}
+
+// Close calls the stop hooks of every constructed singleton of the global container in reverse construction order.
+func Close() error {
+	return Global().Close()
+}
+
+// ResolveCoerce resolves the global binding of the type the source points to into a target of an assignable type.
+func ResolveCoerce(target interface{}, source interface{}) error {
+	return Global().ResolveCoerce(target, source)
+}
+
+// SetObserver sets the observer notified of resolutions from the global container.
+func SetObserver(observer Observer) {
+	Global().SetObserver(observer)
+}
+
+// StartAll starts every binding of the interface type in the global container, rolling back on failure.
+func StartAll(ifacePtr interface{}) error {
+	return Global().StartAll(ifacePtr)
+}
+
+// Bindings returns the metadata of every binding registered in the global container.
+func Bindings() []BindingInfo {
+	return Global().Bindings()
+}
+
+// RegisterFactory makes a factory available to ImportBindings on the global container.
+func RegisterFactory(name string, factory interface{}) error {
+	return Global().RegisterFactory(name, factory)
+}
+
+// ExportBindings serializes the binding definitions of the global container.
+func ExportBindings() ([]byte, error) {
+	return Global().ExportBindings()
+}
+
+// ImportBindings registers the bindings of a manifest in the global container.
+func ImportBindings(data []byte) error {
+	return Global().ImportBindings(data)
+}
+
+// OnMissing sets the handler that supplies instances for missing bindings of the global container.
+func OnMissing(handler MissingHandler) {
+	Global().OnMissing(handler)
+}
+
+// Merge copies the bindings of other into the global container.
+func Merge(other *Container, options ...MergeOption) error {
+	return Global().Merge(other, options...)
+}
+
+// Install registers the modules in the global container.
+func Install(modules ...Module) error {
+	return Global().Install(modules...)
+}
+
+// ConstructionError returns a channel delivering the outcome of a background construction in the global container.
+func ConstructionError(target interface{}) <-chan error {
+	return Global().ConstructionError(target)
+}
+
+// ResolveCapable resolves the bindings of an interface in the global container that also implement a capability.
+func ResolveCapable(ifacePtr interface{}, capabilityPtr interface{}) ([]interface{}, error) {
+	return Global().ResolveCapable(ifacePtr, capabilityPtr)
+}
+
+// Provide binds each factory in the global container.
+func Provide(providers ...interface{}) error {
+	return Global().Provide(providers...)
+}
+
+// BeginScope starts a scope of the global container.
+func BeginScope() *Scope {
+	return Global().BeginScope()
+}
+
+// ClearAndClose closes the cached instances of the global container and removes all its bindings.
+func ClearAndClose() error {
+	return Global().ClearAndClose()
+}
+
+// ResolveValue returns the instance of the type the target points to from the global container.
+func ResolveValue(target interface{}) (interface{}, error) {
+	return Global().ResolveValue(target)
+}
+
+// IsInstantiated reports whether a singleton of the global container has been constructed.
+func IsInstantiated(target interface{}) (bool, error) {
+	return Global().IsInstantiated(target)
+}
+
+// TakeSnapshot captures the registration state of the global container. It is named apart from
+// Container.Snapshot because the package-level name is taken by the Snapshot type.
+func TakeSnapshot() *Snapshot {
+	return Global().Snapshot()
+}
+
+// Restore resets the global container to a snapshot.
+func Restore(s *Snapshot) {
+	Global().Restore(s)
+}
+
+// Replace swaps the factory of an existing binding in the global container.
+func Replace(target interface{}, factory interface{}, options ...BindOption) error {
+	return Global().Replace(target, factory, options...)
+}
+
+// ResolveWith resolves the target from the global container, injecting the override values by type.
+func ResolveWith(target interface{}, overrides map[reflect.Type]interface{}) error {
+	return Global().ResolveWith(target, overrides)
+}
+
+// UnusedBindings returns the singleton bindings of the global container that never produced an instance.
+func UnusedBindings() []BindingInfo {
+	return Global().UnusedBindings()
+}
+
+// SetImplicitInterfaces lets concrete bindings of the global container satisfy interfaces they implement.
+func SetImplicitInterfaces(enabled bool) {
+	Global().SetImplicitInterfaces(enabled)
+}
+
+// ResolveType returns the instance of the binding registered under name for the type t from the global container.
+func ResolveType(t reflect.Type, name string) (interface{}, error) {
+	return Global().ResolveType(t, name)
+}
+
+// Count returns the number of bindings registered in the global container.
+func Count() int {
+	return Global().Count()
+}
+
+// CountFor returns the number of bindings registered in the global container for the type the target points to.
+func CountFor(target interface{}) int {
+	return Global().CountFor(target)
 }
@@ -1,6 +1,7 @@
 package di
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -87,3 +88,216 @@ func TestGlobalFunctions(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestGlobalWrappers(t *testing.T) {
+	bindDatabases := func(t *testing.T) {
+		Clear()
+		t.Cleanup(Clear)
+
+		err := Bind(func() Database {
+			return &mockDatabase{}
+		}, WithTags("sql"))
+		require.NoError(t, err)
+		err = BindNamed("replica", func() Database {
+			return &mockDatabase{}
+		}, WithLabel("role", "replica"))
+		require.NoError(t, err)
+	}
+
+	t.Run("resolution wrappers", func(t *testing.T) {
+		bindDatabases(t)
+
+		var db, fromContainer Database
+		require.NoError(t, ResolveContext(context.Background(), &db))
+		require.NoError(t, Global().Resolve(&fromContainer))
+		assert.Same(t, fromContainer, db)
+
+		require.NoError(t, ResolveFirst(&db, "regional", "replica"))
+		require.NoError(t, Global().ResolveNamed(&fromContainer, "replica"))
+		assert.Same(t, fromContainer, db)
+
+		require.NoError(t, ResolveTransient(&db))
+		require.NoError(t, Global().Resolve(&fromContainer))
+		assert.NotSame(t, fromContainer, db)
+
+		require.NoError(t, ResolveSingleton(&db))
+		assert.Same(t, fromContainer, db)
+
+		var tagged []Database
+		require.NoError(t, ResolveByTag("sql", &tagged))
+		assert.Equal(t, []Database{fromContainer}, tagged)
+
+		named, err := ResolveAllNamed(new(Database))
+		require.NoError(t, err)
+		expected, err := Global().ResolveAllNamed(new(Database))
+		require.NoError(t, err)
+		assert.Equal(t, expected, named)
+
+		replicas, err := ResolveWhere(func(info BindingInfo) bool { return info.Labels["role"] == "replica" })
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{named["replica"]}, replicas)
+
+		assert.Equal(t, Global().FallbackUsed(new(Database), ""), FallbackUsed(new(Database), ""))
+
+		name, err := ImplementationName(new(Database), "replica")
+		require.NoError(t, err)
+		assert.Equal(t, "*di.mockDatabase", name)
+	})
+
+	t.Run("MustResolve", func(t *testing.T) {
+		bindDatabases(t)
+
+		var fromContainer Database
+		require.NoError(t, Global().Resolve(&fromContainer))
+		assert.Same(t, fromContainer, MustResolve[Database]())
+
+		assert.PanicsWithValue(t, "di: failed to resolve di.UserService: no binding found for type di.UserService with name ''", func() {
+			MustResolve[UserService]()
+		})
+	})
+
+	t.Run("ResolveWithLogger", func(t *testing.T) {
+		Clear()
+		t.Cleanup(Clear)
+		err := Bind(func(logger Logger) UserService {
+			logger.Log("constructed")
+			return &userServiceImpl{}
+		})
+		require.NoError(t, err)
+
+		logger := &loggerImpl{}
+		var userService UserService
+		require.NoError(t, ResolveWithLogger(&userService, logger))
+		assert.Equal(t, []string{"constructed"}, logger.messages)
+	})
+
+	t.Run("registration wrappers", func(t *testing.T) {
+		bindDatabases(t)
+
+		require.NoError(t, Decorate(new(Database), func(db Database) Database {
+			require.NoError(t, db.Connect())
+			return db
+		}))
+		var db Database
+		require.NoError(t, Global().Resolve(&db))
+		assert.True(t, db.(*mockDatabase).connected)
+
+		require.NoError(t, Bind(func() *postgresDB { return &postgresDB{} }))
+		require.NoError(t, Alias(new(Database), new(*postgresDB)))
+		var concrete *postgresDB
+		require.NoError(t, Global().Resolve(&concrete))
+		require.NoError(t, Global().Resolve(&db))
+		assert.Same(t, concrete, db)
+
+		require.NoError(t, UnbindNamed(new(Database), "replica"))
+		assert.ErrorIs(t, Global().ResolveNamed(&db, "replica"), ErrBindingNotFound)
+		require.NoError(t, Unbind(new(Database)))
+		assert.ErrorIs(t, Global().Resolve(&db), ErrBindingNotFound)
+
+		err := Transaction(func(tx *Container) error {
+			return tx.BindNamed("replica", func() Database { return &mockDatabase{} })
+		})
+		require.NoError(t, err)
+		assert.NoError(t, Global().ResolveNamed(&db, "replica"))
+	})
+
+	t.Run("lifecycle wrappers", func(t *testing.T) {
+		Clear()
+		t.Cleanup(func() {
+			SetDeferEager(false)
+			SetActiveProfiles()
+			Clear()
+		})
+
+		SetDeferEager(true)
+		constructed := 0
+		err := Bind(func() Database {
+			constructed++
+			return &mockDatabase{}
+		}, WithEager())
+		require.NoError(t, err)
+		assert.Equal(t, 0, constructed)
+		require.NoError(t, Start(context.Background()))
+		assert.Equal(t, 1, constructed)
+
+		require.NoError(t, ResetSingleton(new(Database)))
+		var db Database
+		require.NoError(t, Global().Resolve(&db))
+		assert.Equal(t, 2, constructed)
+
+		err = BindNamed("replica", func() Database {
+			constructed++
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+		require.NoError(t, Global().ResolveNamed(&db, "replica"))
+		require.NoError(t, ResetSingletonNamed(new(Database), "replica"))
+		require.NoError(t, Global().ResolveNamed(&db, "replica"))
+		assert.Equal(t, 4, constructed)
+
+		err = Bind(func() UserService { return &userServiceImpl{} }, WithProfile("dev"))
+		require.NoError(t, err)
+		SetActiveProfiles("dev")
+		var userService UserService
+		assert.NoError(t, Global().Resolve(&userService))
+
+		assert.Equal(t, Global().Validate(), Validate())
+	})
+
+	t.Run("configuration wrappers", func(t *testing.T) {
+		Clear()
+		logger := &recordingLogger{}
+		t.Cleanup(func() {
+			SetPanicOnMissing(false)
+			SetLogger(stdLogger{})
+			RecordTimings(false)
+			Clear()
+		})
+
+		SetPanicOnMissing(true)
+		var db Database
+		assert.Panics(t, func() { _ = Global().Resolve(&db) })
+		SetPanicOnMissing(false)
+
+		SetLogger(logger)
+		require.NoError(t, BindNamed("orphan", func() Database { return &mockDatabase{} }))
+		require.NoError(t, Validate())
+		assert.Len(t, logger.messages, 1)
+
+		RecordTimings(true)
+		require.NoError(t, Global().ResolveNamed(&db, "orphan"))
+		assert.Len(t, ExportTimings(), 1)
+		assert.Equal(t, Global().ExportTimings(), ExportTimings())
+	})
+}
+
+func TestSetGlobal(t *testing.T) {
+	t.Run("package-level functions use the swapped container", func(t *testing.T) {
+		previous := Global()
+		t.Cleanup(func() { SetGlobal(previous) })
+
+		custom := New()
+		db := &mockDatabase{}
+		require.NoError(t, custom.Bind(func() Database { return db }))
+		SetGlobal(custom)
+		assert.Same(t, custom, Global())
+
+		var resolved Database
+		require.NoError(t, Resolve(&resolved))
+		assert.Same(t, db, resolved)
+
+		SetGlobal(previous)
+		assert.Same(t, previous, Global())
+		assert.ErrorIs(t, Resolve(&resolved), ErrBindingNotFound)
+	})
+
+	t.Run("nil installs an empty container", func(t *testing.T) {
+		previous := Global()
+		t.Cleanup(func() { SetGlobal(previous) })
+
+		SetGlobal(nil)
+		require.NotNil(t, Global())
+		assert.NotSame(t, previous, Global())
+		assert.Empty(t, Global().Bindings())
+	})
+}
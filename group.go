@@ -0,0 +1,31 @@
+package di
+
+import (
+	"context"
+	"reflect"
+)
+
+// ResolveGroup returns every binding of T registered with WithGroup(group), in registration order.
+func ResolveGroup[T any](c *Container, group string) ([]T, error) {
+	c.lock.RLock()
+	bindings := c.orderedBindings(reflect.TypeOf((*T)(nil)).Elem())
+	c.lock.RUnlock()
+
+	r := c.begin(context.Background())
+	var members []T
+	for _, b := range bindings {
+		if b.group != group {
+			continue
+		}
+
+		instance, err := b.resolve(c, r)
+		if err != nil {
+			return nil, err
+		}
+
+		member, _ := instance.(T)
+		members = append(members, member)
+	}
+
+	return members, nil
+}
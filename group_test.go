@@ -0,0 +1,132 @@
+package di_test
+
+import (
+	"testing"
+
+	"github.com/ahn84/yadi"
+	"github.com/stretchr/testify/require"
+)
+
+type Handler interface {
+	Route() string
+}
+
+type routeHandler struct {
+	route string
+}
+
+func (h *routeHandler) Route() string {
+	return h.route
+}
+
+type Router struct {
+	Handlers []Handler
+}
+
+func bindHandlers(t *testing.T, c *di.Container, routes ...string) {
+	for _, route := range routes {
+		route := route
+		err := c.Bind(func() Handler {
+			return &routeHandler{route: route}
+		}, di.WithGroup("routes"))
+		require.NoError(t, err)
+	}
+}
+
+func routesOf(handlers []Handler) []string {
+	routes := make([]string, 0, len(handlers))
+	for _, h := range handlers {
+		routes = append(routes, h.Route())
+	}
+	return routes
+}
+
+func TestGroupSliceInjection(t *testing.T) {
+	c := di.New()
+	bindHandlers(t, c, "/users", "/orders", "/health")
+
+	err := c.Bind(func(handlers []Handler) *Router {
+		return &Router{Handlers: handlers}
+	})
+	require.NoError(t, err)
+
+	var router *Router
+	err = c.Resolve(&router)
+	require.NoError(t, err)
+	require.Equal(t, []string{"/users", "/orders", "/health"}, routesOf(router.Handlers))
+}
+
+func TestGroupSliceInjectionIncludesNamedBindings(t *testing.T) {
+	c := di.New()
+	bindHandlers(t, c, "/users")
+
+	err := c.BindNamed("admin", func() Handler {
+		return &routeHandler{route: "/admin"}
+	})
+	require.NoError(t, err)
+
+	err = c.Bind(func(handlers []Handler) *Router {
+		return &Router{Handlers: handlers}
+	})
+	require.NoError(t, err)
+
+	var router *Router
+	err = c.Resolve(&router)
+	require.NoError(t, err)
+	require.Equal(t, []string{"/users", "/admin"}, routesOf(router.Handlers))
+}
+
+func TestSliceBindingTakesPrecedence(t *testing.T) {
+	c := di.New()
+	bindHandlers(t, c, "/users")
+
+	err := c.Bind(func() []Handler {
+		return []Handler{&routeHandler{route: "/explicit"}}
+	})
+	require.NoError(t, err)
+
+	err = c.Bind(func(handlers []Handler) *Router {
+		return &Router{Handlers: handlers}
+	})
+	require.NoError(t, err)
+
+	var router *Router
+	err = c.Resolve(&router)
+	require.NoError(t, err)
+	require.Equal(t, []string{"/explicit"}, routesOf(router.Handlers))
+}
+
+func TestSliceInjectionWithoutBindings(t *testing.T) {
+	c := di.New()
+
+	err := c.Bind(func(handlers []Handler) *Router {
+		return &Router{Handlers: handlers}
+	})
+	require.NoError(t, err)
+
+	var router *Router
+	err = c.Resolve(&router)
+	require.Error(t, err)
+}
+
+func TestResolveGroup(t *testing.T) {
+	c := di.New()
+	bindHandlers(t, c, "/users", "/orders", "/health")
+
+	err := c.BindNamed("admin", func() Handler {
+		return &routeHandler{route: "/admin"}
+	}, di.WithGroup("internal"))
+	require.NoError(t, err)
+
+	handlers, err := di.ResolveGroup[Handler](c, "routes")
+	require.NoError(t, err)
+	require.Equal(t, []string{"/users", "/orders", "/health"}, routesOf(handlers))
+
+	internal, err := di.ResolveGroup[Handler](c, "internal")
+	require.NoError(t, err)
+	require.Equal(t, []string{"/admin"}, routesOf(internal))
+
+	missing, err := di.ResolveGroup[Handler](c, "missing")
+	require.NoError(t, err)
+	require.Empty(t, missing)
+}
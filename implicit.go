@@ -0,0 +1,45 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// SetImplicitInterfaces lets a parameter of an interface type without a binding of its own be satisfied
+// by the default binding of a concrete type implementing the interface, e.g. a Logger parameter by a
+// *consoleLogger binding. Resolution fails if several concrete bindings implement the interface.
+// It is disabled by default, so every interface must be bound explicitly.
+func (c *Container) SetImplicitInterfaces(enabled bool) {
+	c.configure(func(r *registry) {
+		r.implicitInterfaces = enabled
+	})
+}
+
+// implementationBinding finds the default binding of a concrete type implementing the interface t,
+// if implicit interfaces are enabled. It returns nil if there is none and an error if several bindings
+// qualify. The caller must hold c.lock.
+func (c *Container) implementationBinding(t reflect.Type) (*binding, error) {
+	if !c.implicitInterfaces || t.Kind() != reflect.Interface {
+		return nil, nil
+	}
+
+	var found *binding
+	for bindingType, bindings := range c.bindings {
+		if bindingType.Kind() == reflect.Interface || !bindingType.Implements(t) {
+			continue
+		}
+		b, _ := c.active(bindings[""])
+		if b == nil {
+			continue
+		}
+		if found != nil {
+			first, second := found, b
+			if second.before(first) {
+				first, second = second, first
+			}
+			return nil, fmt.Errorf("ambiguous implementations for type %s: %s and %s", t, first.typ, second.typ)
+		}
+		found = b
+	}
+	return found, nil
+}
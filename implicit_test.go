@@ -0,0 +1,87 @@
+package di
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type consoleLogger struct {
+	messages []string
+}
+
+func (l *consoleLogger) Log(message string) {
+	l.messages = append(l.messages, message)
+}
+
+type fileLogger struct{}
+
+func (fileLogger) Log(string) {}
+
+func TestContainer_SetImplicitInterfaces(t *testing.T) {
+	bindOrderService := func(t *testing.T, container *Container) {
+		err := container.Bind(func(logger Logger) OrderService {
+			return &orderServiceImpl{logger: logger}
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("single implementation satisfies the interface", func(t *testing.T) {
+		container := New()
+		container.SetImplicitInterfaces(true)
+		logger := &consoleLogger{}
+		require.NoError(t, container.Bind(func() *consoleLogger { return logger }))
+		bindOrderService(t, container)
+		require.NoError(t, container.Validate())
+
+		var orderService OrderService
+		require.NoError(t, container.Resolve(&orderService))
+		assert.Same(t, logger, orderService.(*orderServiceImpl).logger)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		container := New()
+		require.NoError(t, container.Bind(func() *consoleLogger { return &consoleLogger{} }))
+		bindOrderService(t, container)
+
+		var orderService OrderService
+		assert.ErrorIs(t, container.Resolve(&orderService), ErrBindingNotFound)
+	})
+
+	t.Run("no implementation", func(t *testing.T) {
+		container := New()
+		container.SetImplicitInterfaces(true)
+		require.NoError(t, container.Bind(func() *mockDatabase { return &mockDatabase{} }))
+		bindOrderService(t, container)
+
+		var orderService OrderService
+		assert.ErrorIs(t, container.Resolve(&orderService), ErrBindingNotFound)
+	})
+
+	t.Run("several implementations are ambiguous", func(t *testing.T) {
+		container := New()
+		container.SetImplicitInterfaces(true)
+		require.NoError(t, container.Bind(func() *consoleLogger { return &consoleLogger{} }))
+		require.NoError(t, container.Bind(func() fileLogger { return fileLogger{} }))
+		bindOrderService(t, container)
+
+		var orderService OrderService
+		err := container.Resolve(&orderService)
+		assert.EqualError(t, err, "ambiguous implementations for type di.Logger: *di.consoleLogger and di.fileLogger")
+	})
+
+	t.Run("explicit binding takes precedence", func(t *testing.T) {
+		container := New()
+		container.SetImplicitInterfaces(true)
+		logger := &loggerImpl{}
+		require.NoError(t, container.Bind(func() *consoleLogger { return &consoleLogger{} }))
+		require.NoError(t, container.Bind(func() fileLogger { return fileLogger{} }))
+		require.NoError(t, container.Bind(func() Logger { return logger }))
+		bindOrderService(t, container)
+
+		var orderService OrderService
+		require.NoError(t, container.Resolve(&orderService))
+		assert.Same(t, logger, orderService.(*orderServiceImpl).logger)
+	})
+}
@@ -0,0 +1,179 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// BindingInfo describes a registered binding.
+type BindingInfo struct {
+	Type         reflect.Type      // type the binding resolves to
+	Name         string            // binding name, empty for the default binding
+	Singleton    bool              // whether the binding is a singleton
+	Instantiated bool              // whether a singleton instance is cached, always false for WithKeyFunc and WithContextKey bindings
+	Labels       map[string]string // labels attached with WithLabel
+	Tags         []string          // tags attached with WithTags
+}
+
+func (b *binding) info() BindingInfo {
+	labels := make(map[string]string, len(b.labels))
+	for key, value := range b.labels {
+		labels[key] = value
+	}
+
+	return BindingInfo{
+		Type:         b.typ,
+		Name:         b.name,
+		Singleton:    b.singleton,
+		Instantiated: b.state().concrete.Load() != nil,
+		Labels:       labels,
+		Tags:         append([]string(nil), b.tags...),
+	}
+}
+
+// Bindings returns a snapshot of the metadata of every registered binding, in registration order.
+func (c *Container) Bindings() []BindingInfo {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	var infos []BindingInfo
+	for _, b := range c.allBindings() {
+		infos = append(infos, b.info())
+	}
+	return infos
+}
+
+// Count returns the number of registered bindings across all types and names. Every candidate registered
+// for a type and name with WithCondition, WithProfile or WithPriority is counted, including those that do not
+// currently take effect; Bindings only reports the ones that do.
+func (c *Container) Count() int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	count := 0
+	for _, bindings := range c.bindings {
+		count += countCandidates(bindings)
+	}
+	return count
+}
+
+// CountFor returns the number of bindings registered for the type the target points to, named ones
+// and candidates that do not currently take effect included, like Count. It returns 0 if the target is not a pointer.
+func (c *Container) CountFor(target interface{}) int {
+	targetType := reflect.TypeOf(target)
+	if targetType == nil || targetType.Kind() != reflect.Ptr {
+		return 0
+	}
+
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return countCandidates(c.bindings[targetType.Elem()])
+}
+
+// countCandidates returns the number of bindings registered under the names, following the chains of candidates.
+func countCandidates(bindings map[string]*binding) int {
+	count := 0
+	for _, b := range bindings {
+		for ; b != nil; b = b.next {
+			count++
+		}
+	}
+	return count
+}
+
+// ResolveWhere resolves every binding, across all types, whose metadata matches the predicate.
+func (c *Container) ResolveWhere(pred func(BindingInfo) bool) ([]interface{}, error) {
+	c.lock.RLock()
+	bindings := c.allBindings()
+	c.lock.RUnlock()
+
+	r := c.begin(context.Background())
+	var instances []interface{}
+	for _, binding := range bindings {
+		if !pred(binding.info()) {
+			continue
+		}
+
+		instance, err := binding.resolve(c, r)
+		if err != nil {
+			return nil, err
+		}
+		instances = append(instances, instance)
+	}
+
+	return instances, nil
+}
+
+// IsInstantiated reports whether the default binding for the type the target points to has cached
+// its singleton instance, without constructing it. It is always false for transient bindings.
+func (c *Container) IsInstantiated(target interface{}) (bool, error) {
+	targetType := reflect.TypeOf(target)
+	if targetType == nil || targetType.Kind() != reflect.Ptr {
+		return false, ErrNotAPointer
+	}
+
+	b, err := c.lookup(targetType.Elem(), "")
+	if err != nil {
+		return false, err
+	}
+	if b == nil {
+		return false, fmt.Errorf("%w for type %s with name ''", ErrBindingNotFound, targetType.Elem())
+	}
+	return b.singleton && b.state().concrete.Load() != nil, nil
+}
+
+// UnusedBindings returns the metadata of the singleton bindings, in registration order, that never produced
+// an instance since they were registered, e.g. to log dead wiring once the application has started.
+// Transient and scoped bindings are not reported since they keep no state to tell whether they were used.
+func (c *Container) UnusedBindings() []BindingInfo {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	var unused []BindingInfo
+	for _, b := range c.allBindings() {
+		if b.singleton && !b.state().constructed.Load() {
+			unused = append(unused, b.info())
+		}
+	}
+	return unused
+}
+
+// markConstructed records that the binding produced an instance, including for the name-aware binding
+// it was created from.
+func (b *binding) markConstructed() {
+	b.constructed.Store(true)
+	if b.parent != nil {
+		b.parent.constructed.Store(true)
+	}
+}
+
+// ImplementationName returns the name of the concrete type produced by the binding registered under name
+// for the type the target points to, e.g. "*app.userServiceImpl" for a UserService binding. Bindings of
+// concrete types are answered from the factory signature; interface bindings are resolved to inspect the instance.
+func (c *Container) ImplementationName(target interface{}, name string) (string, error) {
+	targetType := reflect.TypeOf(target)
+	if targetType == nil || targetType.Kind() != reflect.Ptr {
+		return "", ErrNotAPointer
+	}
+
+	b, err := c.lookup(targetType.Elem(), name)
+	if err != nil {
+		return "", err
+	}
+	if b == nil {
+		return "", fmt.Errorf("%w for type %s with name '%s'", ErrBindingNotFound, targetType.Elem(), name)
+	}
+	if b.typ.Kind() != reflect.Interface {
+		return b.typ.String(), nil
+	}
+
+	instance, err := b.resolve(c, c.begin(context.Background()))
+	if err != nil {
+		return "", err
+	}
+	if instance == nil {
+		return b.typ.String(), nil
+	}
+	return reflect.TypeOf(instance).String(), nil
+}
@@ -0,0 +1,251 @@
+package di
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer_ResolveWhere(t *testing.T) {
+	t.Run("resolve only labeled bindings", func(t *testing.T) {
+		container := New()
+		db := &mockDatabase{}
+		logger := &loggerImpl{}
+		constructed := 0
+
+		err := container.Bind(func() Database {
+			constructed++
+			return db
+		}, WithLabel("phase", "startup"))
+		require.NoError(t, err)
+
+		err = container.Bind(func() Logger {
+			constructed++
+			return logger
+		}, WithLabel("phase", "startup"))
+		require.NoError(t, err)
+
+		err = container.Bind(func() UserService {
+			constructed++
+			return &userServiceImpl{}
+		}, WithLabel("phase", "background"))
+		require.NoError(t, err)
+
+		instances, err := container.ResolveWhere(func(info BindingInfo) bool {
+			return info.Labels["phase"] == "startup"
+		})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []interface{}{db, logger}, instances)
+		assert.Equal(t, 2, constructed)
+	})
+
+	t.Run("predicate receives binding metadata", func(t *testing.T) {
+		container := New()
+
+		err := container.BindNamedTransient("replica", func() Database {
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		var infos []BindingInfo
+		instances, err := container.ResolveWhere(func(info BindingInfo) bool {
+			infos = append(infos, info)
+			return false
+		})
+		require.NoError(t, err)
+		assert.Empty(t, instances)
+		require.Len(t, infos, 1)
+		assert.Equal(t, "replica", infos[0].Name)
+		assert.Equal(t, "di.Database", infos[0].Type.String())
+		assert.False(t, infos[0].Singleton)
+	})
+
+	t.Run("factory errors are returned", func(t *testing.T) {
+		container := New()
+
+		err := container.Bind(func(logger Logger) Database {
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		_, err = container.ResolveWhere(func(info BindingInfo) bool {
+			return true
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestBindingInfo_Tags(t *testing.T) {
+	container := New()
+
+	err := container.Bind(func() Database {
+		return &mockDatabase{}
+	}, WithTags("critical", "storage"))
+	require.NoError(t, err)
+
+	instances, err := container.ResolveWhere(func(info BindingInfo) bool {
+		for _, tag := range info.Tags {
+			if tag == "storage" {
+				return true
+			}
+		}
+		return false
+	})
+	require.NoError(t, err)
+	assert.Len(t, instances, 1)
+}
+
+func TestContainer_ImplementationName(t *testing.T) {
+	t.Run("interface binding is resolved", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func() Database {
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+		err = container.BindNamed("users", func(db Database) UserService {
+			return &userServiceImpl{db: db}
+		})
+		require.NoError(t, err)
+
+		name, err := container.ImplementationName(new(UserService), "users")
+		require.NoError(t, err)
+		assert.Equal(t, "*di.userServiceImpl", name)
+	})
+
+	t.Run("concrete binding is not constructed", func(t *testing.T) {
+		container := New()
+		called := false
+		err := container.Bind(func() *mockDatabase {
+			called = true
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		name, err := container.ImplementationName(new(*mockDatabase), "")
+		require.NoError(t, err)
+		assert.Equal(t, "*di.mockDatabase", name)
+		assert.False(t, called)
+	})
+
+	t.Run("missing binding", func(t *testing.T) {
+		container := New()
+
+		_, err := container.ImplementationName(new(Database), "")
+		assert.ErrorIs(t, err, ErrBindingNotFound)
+	})
+}
+
+func TestContainer_Bindings(t *testing.T) {
+	container := New()
+	err := container.Bind(func() Database {
+		return &mockDatabase{}
+	}, WithLabel("tier", "storage"))
+	require.NoError(t, err)
+	err = container.BindNamed("audit", func() Logger {
+		return &loggerImpl{}
+	}, WithTransient(), WithTags("audit"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []BindingInfo{
+		{Type: reflect.TypeOf((*Database)(nil)).Elem(), Singleton: true, Labels: map[string]string{"tier": "storage"}},
+		{Type: loggerType, Name: "audit", Labels: map[string]string{}, Tags: []string{"audit"}},
+	}, container.Bindings())
+
+	var db Database
+	require.NoError(t, container.Resolve(&db))
+	var logger Logger
+	require.NoError(t, container.ResolveNamed(&logger, "audit"))
+
+	infos := container.Bindings()
+	assert.True(t, infos[0].Instantiated)
+	assert.False(t, infos[1].Instantiated)
+
+	// The snapshot is a copy
+	infos[0].Labels["tier"] = "changed"
+	assert.Equal(t, "storage", container.Bindings()[0].Labels["tier"])
+}
+
+func TestContainer_IsInstantiated(t *testing.T) {
+	container := New()
+	require.NoError(t, container.Bind(func() Database { return &mockDatabase{} }))
+	require.NoError(t, container.BindTransient(func() Logger { return &loggerImpl{} }))
+
+	instantiated, err := container.IsInstantiated(new(Database))
+	require.NoError(t, err)
+	assert.False(t, instantiated)
+
+	var db Database
+	require.NoError(t, container.Resolve(&db))
+	instantiated, err = container.IsInstantiated(new(Database))
+	require.NoError(t, err)
+	assert.True(t, instantiated)
+
+	var logger Logger
+	require.NoError(t, container.Resolve(&logger))
+	instantiated, err = container.IsInstantiated(new(Logger))
+	require.NoError(t, err)
+	assert.False(t, instantiated)
+
+	_, err = container.IsInstantiated(new(UserService))
+	assert.ErrorIs(t, err, ErrBindingNotFound)
+}
+
+func TestContainer_UnusedBindings(t *testing.T) {
+	container := New()
+	require.NoError(t, container.Bind(func() Database { return &mockDatabase{} }))
+	require.NoError(t, container.Bind(func() UserService { return &userServiceImpl{} }))
+	require.NoError(t, container.BindNamed("audit", func() Logger { return &loggerImpl{} }))
+	require.NoError(t, container.BindTransient(func() OrderService { return &orderServiceImpl{} }))
+
+	var db Database
+	require.NoError(t, container.Resolve(&db))
+
+	unused := container.UnusedBindings()
+	require.Len(t, unused, 2)
+	assert.Equal(t, reflect.TypeOf((*UserService)(nil)).Elem(), unused[0].Type)
+	assert.Equal(t, reflect.TypeOf((*Logger)(nil)).Elem(), unused[1].Type)
+	assert.Equal(t, "audit", unused[1].Name)
+
+	// A reset singleton was still used
+	require.NoError(t, container.ResetSingleton(new(Database)))
+	assert.Len(t, container.UnusedBindings(), 2)
+}
+
+func TestContainer_Count(t *testing.T) {
+	container := New()
+	assert.Equal(t, 0, container.Count())
+	assert.Equal(t, 0, container.CountFor(new(Database)))
+
+	require.NoError(t, container.Bind(func() Database { return &mockDatabase{} }))
+	require.NoError(t, container.Bind(func() UserService { return &userServiceImpl{} }))
+	assert.Equal(t, 2, container.Count())
+	assert.Equal(t, 1, container.CountFor(new(Database)))
+
+	require.NoError(t, container.BindNamed("replica", func() Database { return &mockDatabase{} }))
+	require.NoError(t, container.BindNamed("audit", func() Database { return &mockDatabase{} }))
+	assert.Equal(t, 4, container.Count())
+	assert.Equal(t, 3, container.CountFor(new(Database)))
+	assert.Equal(t, 1, container.CountFor(new(UserService)))
+	assert.Equal(t, 0, container.CountFor(Database(nil)))
+
+	container.Clear()
+	assert.Equal(t, 0, container.Count())
+	assert.Equal(t, 0, container.CountFor(new(Database)))
+}
+
+func TestContainer_CountCandidates(t *testing.T) {
+	container := New()
+	container.SetActiveProfiles("prod")
+
+	require.NoError(t, container.Bind(func() Database { return &mockDatabase{} }))
+	require.NoError(t, container.Bind(func() Database { return &mockDatabase{} }, WithCondition(func() bool { return false })))
+	require.NoError(t, container.Bind(func() Database { return &mockDatabase{} }, WithCondition(func() bool { return true })))
+	require.NoError(t, container.Bind(func() UserService { return &userServiceImpl{} }, WithProfile("dev")))
+
+	assert.Equal(t, 4, container.Count())
+	assert.Equal(t, 3, container.CountFor(new(Database)))
+	assert.Equal(t, 1, container.CountFor(new(UserService)))
+	assert.Len(t, container.Bindings(), 1)
+}
@@ -0,0 +1,108 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// multitonKey identifies a keyed factory by its key and instance types.
+type multitonKey struct {
+	key, typ reflect.Type
+}
+
+// multiton holds the factory registered with BindKeyed and the instances it built, one per key.
+type multiton[K comparable, T any] struct {
+	factory   func(K) T
+	mutex     sync.Mutex
+	instances map[K]T
+}
+
+// keyedInstances is implemented by every multiton, whatever its key and instance types.
+type keyedInstances interface {
+	// clear removes the cached instances and returns them.
+	clear() []any
+}
+
+// clear removes the cached instances and returns them.
+func (m *multiton[K, T]) clear() []any {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	instances := make([]any, 0, len(m.instances))
+	for _, instance := range m.instances {
+		instances = append(instances, instance)
+	}
+	m.instances = make(map[K]T)
+	return instances
+}
+
+// BindKeyed registers a factory building one instance of T per key, e.g. one service per tenant.
+// Instances are constructed on first resolution with ResolveKeyed and cached per key. Registering
+// another factory for the same key and instance types replaces the previous one and its instances.
+func BindKeyed[K comparable, T any](c *Container, factory func(K) T) error {
+	if factory == nil {
+		return fmt.Errorf("container: the factory %w", ErrNotAFunction)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.multitons == nil {
+		c.multitons = make(map[multitonKey]any)
+	}
+	c.multitons[multitonKey{key: typeOf[K](), typ: typeOf[T]()}] = &multiton[K, T]{
+		factory:   factory,
+		instances: make(map[K]T),
+	}
+	return nil
+}
+
+// ResolveKeyed returns the instance of T for the key, constructing it with the factory registered
+// with BindKeyed if it is not cached yet.
+func ResolveKeyed[K comparable, T any](c *Container, key K) (T, error) {
+	c.lock.RLock()
+	m, exists := c.multitons[multitonKey{key: typeOf[K](), typ: typeOf[T]()}].(*multiton[K, T])
+	c.lock.RUnlock()
+
+	if !exists {
+		var zero T
+		return zero, fmt.Errorf("%w for type %s keyed by %s", ErrBindingNotFound, typeOf[T](), typeOf[K]())
+	}
+
+	m.mutex.Lock()
+	instance, cached := m.instances[key]
+	m.mutex.Unlock()
+	if cached {
+		return instance, nil
+	}
+
+	// The factory runs without the lock, so it may resolve other keys
+	instance, err := m.build(key)
+	if err != nil {
+		return instance, err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if existing, cached := m.instances[key]; cached {
+		return existing, nil
+	}
+	m.instances[key] = instance
+	return instance, nil
+}
+
+// build calls the factory for the key, converting a panic into an error like the factories of bindings.
+func (m *multiton[K, T]) build(key K) (instance T, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic while constructing %s for key %v: %v", typeOf[T](), key, p)
+		}
+	}()
+	return m.factory(key), nil
+}
+
+// typeOf returns the reflect.Type of T, including interface types.
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
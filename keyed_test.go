@@ -0,0 +1,59 @@
+package di
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tenantService struct {
+	tenant string
+}
+
+func TestBindKeyed(t *testing.T) {
+	t.Run("instances are cached per key", func(t *testing.T) {
+		container := New()
+		constructed := 0
+		err := BindKeyed(container, func(tenant string) *tenantService {
+			constructed++
+			return &tenantService{tenant: tenant}
+		})
+		require.NoError(t, err)
+
+		acme, err := ResolveKeyed[string, *tenantService](container, "acme")
+		require.NoError(t, err)
+		again, err := ResolveKeyed[string, *tenantService](container, "acme")
+		require.NoError(t, err)
+		globex, err := ResolveKeyed[string, *tenantService](container, "globex")
+		require.NoError(t, err)
+
+		assert.Same(t, acme, again)
+		assert.NotSame(t, acme, globex)
+		assert.Equal(t, "globex", globex.tenant)
+		assert.Equal(t, 2, constructed)
+	})
+
+	t.Run("ClearAndClose removes keyed factories and closes their instances", func(t *testing.T) {
+		container := New()
+		require.NoError(t, BindKeyed(container, func(dsn string) *closableConnection {
+			return &closableConnection{dsn: dsn}
+		}))
+
+		primary, err := ResolveKeyed[string, *closableConnection](container, "primary")
+		require.NoError(t, err)
+
+		require.NoError(t, container.ClearAndClose())
+		assert.True(t, primary.closed)
+		_, err = ResolveKeyed[string, *closableConnection](container, "primary")
+		assert.ErrorIs(t, err, ErrBindingNotFound)
+	})
+
+	t.Run("missing keyed factory", func(t *testing.T) {
+		container := New()
+
+		_, err := ResolveKeyed[int, Database](container, 1)
+		assert.ErrorIs(t, err, ErrBindingNotFound)
+		assert.ErrorContains(t, err, "di.Database keyed by int")
+	})
+}
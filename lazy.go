@@ -2,21 +2,64 @@ package di
 
 import (
 	"reflect"
-	"strings"
 )
 
-// Lazy is a helper type for lazy dependency resolution.
+// Lazy is a helper type for lazy dependency resolution. A Lazy[T] without a Container, such as the zero
+// value Lazy[T]{}, resolves from the global container.
 type Lazy[T any] struct {
 	Container *Container
+	name      string // binding name used by Resolve, empty for the default binding
+}
+
+// LazyNamed returns a Lazy[T] that resolves the binding registered under name.
+func LazyNamed[T any](c *Container, name string) Lazy[T] {
+	return Lazy[T]{Container: c, name: name}
 }
 
 // Resolve resolves the dependency.
 func (l *Lazy[T]) Resolve() (T, error) {
+	return l.ResolveNamed(l.name)
+}
+
+// ResolveNamed resolves the dependency registered under the given name.
+func (l *Lazy[T]) ResolveNamed(name string) (T, error) {
+	c := l.Container
+	if c == nil {
+		c = Global()
+	}
+
 	var instance T
-	err := l.Container.Resolve(&instance)
+	err := c.ResolveNamed(&instance, name)
 	return instance, err
 }
 
+// lazyType marks Lazy[T] instantiations so they can be detected via reflection. It returns the Lazy[T]
+// type itself, since the method is also promoted to structs embedding a Lazy[T].
+func (Lazy[T]) lazyType() reflect.Type {
+	return reflect.TypeOf(Lazy[T]{})
+}
+
+// lazyMarker is implemented by every instantiation of Lazy[T] and the structs embedding one.
+type lazyMarker interface {
+	lazyType() reflect.Type
+}
+
+var lazyMarkerType = reflect.TypeOf((*lazyMarker)(nil)).Elem()
+
 func isLazy(t reflect.Type) bool {
-	return t.Kind() == reflect.Struct && strings.HasPrefix(t.Name(), "Lazy[")
-}
\ No newline at end of file
+	return t.Kind() == reflect.Struct && t.Implements(lazyMarkerType) &&
+		reflect.Zero(t).Interface().(lazyMarker).lazyType() == t
+}
+
+// lazyElem returns T for a Lazy[T] type.
+func lazyElem(t reflect.Type) reflect.Type {
+	resolve, _ := reflect.PtrTo(t).MethodByName("Resolve")
+	return resolve.Type.Out(0)
+}
+
+// newLazy constructs a Lazy[T] value of the given type that resolves from c.
+func newLazy(t reflect.Type, c *Container) reflect.Value {
+	lazyValue := reflect.New(t).Elem()
+	lazyValue.FieldByName("Container").Set(reflect.ValueOf(c))
+	return lazyValue
+}
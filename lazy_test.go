@@ -94,3 +94,147 @@ func TestLazyConstructor(t *testing.T) {
 	// Now ServiceF constructor should have been called
 	require.Equal(t, 2, constructorCallCount)
 }
+
+// LazyLoader is a user type whose name merely resembles Lazy and must be
+// resolved through its own binding.
+type LazyLoader struct {
+	Source string
+}
+
+type LoaderConsumer struct {
+	Loader LazyLoader
+}
+
+func TestLazyDetectionIgnoresSimilarNames(t *testing.T) {
+	c := di.New()
+
+	err := c.Bind(func() LazyLoader {
+		return LazyLoader{Source: "bound"}
+	})
+	require.NoError(t, err)
+
+	err = c.Bind(func(loader LazyLoader) *LoaderConsumer {
+		return &LoaderConsumer{Loader: loader}
+	})
+	require.NoError(t, err)
+
+	var consumer *LoaderConsumer
+	err = c.Resolve(&consumer)
+	require.NoError(t, err)
+	require.Equal(t, "bound", consumer.Loader.Source)
+}
+
+type Foo struct {
+	Value string
+}
+
+type FooConsumer struct {
+	Foo di.Lazy[Foo]
+}
+
+func TestLazyParameterWithoutBinding(t *testing.T) {
+	c := di.New()
+
+	err := c.Bind(func(foo di.Lazy[Foo]) *FooConsumer {
+		return &FooConsumer{Foo: foo}
+	})
+	require.NoError(t, err)
+
+	err = c.Bind(func() Foo {
+		return Foo{Value: "foo"}
+	})
+	require.NoError(t, err)
+
+	var consumer *FooConsumer
+	err = c.Resolve(&consumer)
+	require.NoError(t, err)
+	require.Equal(t, c.Bindings(), consumer.Foo.Container.Bindings())
+
+	foo, err := consumer.Foo.Resolve()
+	require.NoError(t, err)
+	require.Equal(t, "foo", foo.Value)
+}
+
+type LazyHolder struct {
+	di.Lazy[Foo]
+	Label string
+}
+
+func TestLazyEmbeddedInStructIsNotLazy(t *testing.T) {
+	c := di.New()
+
+	err := c.Bind(func() LazyHolder {
+		return LazyHolder{Label: "bound"}
+	})
+	require.NoError(t, err)
+
+	err = c.Bind(func(holder LazyHolder) string {
+		return holder.Label
+	})
+	require.NoError(t, err)
+
+	var label string
+	err = c.Resolve(&label)
+	require.NoError(t, err)
+	require.Equal(t, "bound", label)
+}
+
+type namedLogger struct {
+	target string
+}
+
+func (l *namedLogger) Log(message string) {}
+
+func TestLazyNamedResolve(t *testing.T) {
+	c := di.New()
+
+	err := c.BindNamed("console", func() di.Logger {
+		return &namedLogger{target: "console"}
+	})
+	require.NoError(t, err)
+
+	err = c.BindNamed("file", func() di.Logger {
+		return &namedLogger{target: "file"}
+	})
+	require.NoError(t, err)
+
+	t.Run("resolve named on demand", func(t *testing.T) {
+		lazy := di.Lazy[di.Logger]{Container: c}
+
+		logger, err := lazy.ResolveNamed("file")
+		require.NoError(t, err)
+		require.Equal(t, "file", logger.(*namedLogger).target)
+	})
+
+	t.Run("lazy bound to a name", func(t *testing.T) {
+		lazy := di.LazyNamed[di.Logger](c, "file")
+
+		logger, err := lazy.Resolve()
+		require.NoError(t, err)
+		require.Equal(t, "file", logger.(*namedLogger).target)
+	})
+
+	t.Run("default name is not bound", func(t *testing.T) {
+		lazy := di.Lazy[di.Logger]{Container: c}
+
+		_, err := lazy.Resolve()
+		require.Error(t, err)
+	})
+}
+
+func TestLazyZeroValueResolvesFromGlobal(t *testing.T) {
+	previous := di.Global()
+	t.Cleanup(func() { di.SetGlobal(previous) })
+	di.SetGlobal(di.New())
+
+	var lazy di.Lazy[*ServiceF]
+	_, err := lazy.Resolve()
+	require.ErrorIs(t, err, di.ErrBindingNotFound)
+
+	serviceF := &ServiceF{}
+	require.NoError(t, di.Bind(func() *ServiceF { return serviceF }))
+
+	resolved, err := lazy.Resolve()
+	require.NoError(t, err)
+	require.Same(t, serviceF, resolved)
+}
@@ -0,0 +1,352 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// SetDeferEager controls when bindings registered with WithEager are instantiated.
+// By default they are instantiated during Bind, which requires their dependencies to be registered first.
+// When deferred, they are instantiated by Start instead, so bindings can be registered in any order.
+func (c *Container) SetDeferEager(deferEager bool) {
+	c.configure(func(r *registry) {
+		r.deferEager = deferEager
+	})
+}
+
+// Startable is implemented by singletons that need to be started by Container.Start.
+type Startable interface {
+	Start(ctx context.Context) error
+}
+
+// Stoppable is implemented by singletons that need to be stopped by Container.Stop.
+type Stoppable interface {
+	Stop(ctx context.Context) error
+}
+
+// Start instantiates every eager binding whose construction was deferred with SetDeferEager,
+// in registration order, and then calls Start on every constructed singleton implementing Startable
+// that has not been started yet. Singletons are started in construction order, so dependencies start
+// before the instances built from them. Start stops at the first error and reports what failed.
+func (c *Container) Start(ctx context.Context) error {
+	c.lock.RLock()
+	bindings := c.allBindings()
+	c.lock.RUnlock()
+
+	r := c.begin(ctx)
+	for _, b := range bindings {
+		if !b.eager {
+			continue
+		}
+		if _, err := b.obtain(c, r, lifetimeDefault); err != nil {
+			if b.bestEffort {
+				c.skip(b, err)
+				continue
+			}
+			return fmt.Errorf("starting binding for type %s with name '%s': %w", b.typ, b.name, err)
+		}
+	}
+
+	c.lock.RLock()
+	pending := c.services[c.running:]
+	c.lock.RUnlock()
+
+	for _, service := range pending {
+		if startable, ok := service.(Startable); ok {
+			if err := startable.Start(ctx); err != nil {
+				return fmt.Errorf("starting %T: %w", service, err)
+			}
+		}
+		c.lock.Lock()
+		c.running++
+		c.lock.Unlock()
+	}
+
+	return nil
+}
+
+// Stop calls Stop on every singleton started by Start that implements Stoppable, in reverse start order.
+// Every service is stopped even if an earlier one fails; their errors are joined. A later Start starts
+// the stopped singletons again.
+func (c *Container) Stop(ctx context.Context) error {
+	c.lock.Lock()
+	running := c.services[:c.running]
+	c.running = 0
+	c.lock.Unlock()
+
+	var errs []error
+	for i := len(running) - 1; i >= 0; i-- {
+		if stoppable, ok := running[i].(Stoppable); ok {
+			if err := stoppable.Stop(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("stopping %T: %w", running[i], err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// StartAll resolves every binding of the interface type the pointer points to, in registration order,
+// and calls Start() error on each instance. If one fails, the instances started before it are rolled back
+// by calling their Stop() error method, if they have one, in reverse order. Every instance must have a
+// Start() error method.
+func (c *Container) StartAll(ifacePtr interface{}) error {
+	targetType := reflect.TypeOf(ifacePtr)
+	if targetType == nil || targetType.Kind() != reflect.Ptr {
+		return ErrNotAPointer
+	}
+
+	c.lock.RLock()
+	bindings := c.orderedBindings(targetType.Elem())
+	c.lock.RUnlock()
+
+	r := c.begin(context.Background())
+	var started []any
+	for _, b := range bindings {
+		instance, err := b.resolve(c, r)
+		if err == nil {
+			if startable, ok := instance.(interface{ Start() error }); !ok {
+				err = fmt.Errorf("cannot start %T: no Start() error method", instance)
+			} else if err = startable.Start(); err != nil {
+				err = fmt.Errorf("starting %T: %w", instance, err)
+			}
+		}
+
+		switch {
+		case err == nil:
+			started = append(started, instance)
+		case b.bestEffort:
+			c.skip(b, err)
+		default:
+			return errors.Join(err, rollback(started))
+		}
+	}
+	return nil
+}
+
+// WithBestEffort makes failures of the binding during startup non-fatal: if its eager construction,
+// its construction by Start or its start by StartAll fails, the error is logged and startup carries on
+// without it. The binding stays registered, and later resolutions construct it again, possibly failing.
+func WithBestEffort() BindOption {
+	return func(config *bindConfig) {
+		config.bestEffort = true
+	}
+}
+
+// skip logs the startup failure of a best-effort binding.
+func (c *Container) skip(b *binding, err error) {
+	c.log(fmt.Sprintf("di: skipping best-effort binding for type %s with name '%s': %v", b.typ, b.name, err))
+}
+
+// rollback stops the started instances in reverse order and joins the errors of their Stop methods.
+func rollback(started []any) error {
+	var errs []error
+	for i := len(started) - 1; i >= 0; i-- {
+		if stoppable, ok := started[i].(interface{ Stop() error }); ok {
+			if err := stoppable.Stop(); err != nil {
+				errs = append(errs, fmt.Errorf("stopping %T: %w", started[i], err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ResetSingleton discards the cached instance of the default binding for the type the target points to,
+// so the next resolution invokes the factory again. The discarded instance is stopped if Start started it,
+// its stop hook runs, and it is closed if it implements io.Closer.
+func (c *Container) ResetSingleton(target interface{}) error {
+	return c.ResetSingletonNamed(target, "")
+}
+
+// ResetSingletonNamed is like ResetSingleton for the binding registered under name.
+func (c *Container) ResetSingletonNamed(target interface{}, name string) error {
+	targetType := reflect.TypeOf(target)
+	if targetType == nil || targetType.Kind() != reflect.Ptr {
+		return ErrNotAPointer
+	}
+
+	b, err := c.lookup(targetType.Elem(), name)
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		return fmt.Errorf("%w for type %s with name '%s'", ErrBindingNotFound, targetType.Elem(), name)
+	}
+
+	c.invalidate(b)
+	return nil
+}
+
+// WithReactive makes a singleton reset whenever one of its dependencies is reset with ResetSingleton,
+// so it is rebuilt from the new instances on the next resolution. Resets propagate through chains of
+// reactive bindings.
+func WithReactive() BindOption {
+	return func(config *bindConfig) {
+		config.reactive = true
+	}
+}
+
+// invalidate discards the binding's cached instances and those of the reactive bindings built from them.
+func (c *Container) invalidate(b *binding) {
+	// Bindings shared with the container a transaction stages are invalidated there on commit,
+	// and replaced by a copy in the meantime
+	if c.isInherited(b) {
+		c.lock.Lock()
+		c.invalidated = append(c.invalidated, b)
+		c.lock.Unlock()
+
+		if owned := c.own(b); owned != nil {
+			c.invalidate(owned)
+			return
+		}
+		b.dependents.Range(func(key, _ any) bool {
+			c.invalidate(key.(*binding))
+			return true
+		})
+		return
+	}
+
+	b = b.state()
+	for _, instance := range b.reset() {
+		c.discard(instance)
+	}
+
+	b.dependents.Range(func(key, _ any) bool {
+		b.dependents.Delete(key)
+		c.invalidate(key.(*binding))
+		return true
+	})
+}
+
+// discard releases an instance that was reset: it is dropped from the services Start and Stop manage and
+// stopped if it was running, its stop hook runs, and it is closed. Failures are logged.
+func (c *Container) discard(instance any) {
+	if !reflect.TypeOf(instance).Comparable() {
+		c.dispose(instance)
+		return
+	}
+
+	// The lists are copied rather than changed in place, since Start, Stop and Close iterate them unlocked
+	c.lock.Lock()
+	running := false
+	if i := slices.Index(c.services, instance); i >= 0 {
+		running = i < c.running
+		if running {
+			c.running--
+		}
+		c.services = append(slices.Clone(c.services[:i]), c.services[i+1:]...)
+	}
+	var hooks, kept []stopper
+	for _, s := range c.stoppers {
+		if s.instance == instance {
+			hooks = append(hooks, s)
+		} else {
+			kept = append(kept, s)
+		}
+	}
+	if len(hooks) > 0 {
+		c.stoppers = kept
+	}
+	c.lock.Unlock()
+
+	if stoppable, ok := instance.(Stoppable); ok && running {
+		if err := stoppable.Stop(context.Background()); err != nil {
+			c.log(fmt.Sprintf("di: stopping %T failed: %v", instance, err))
+		}
+	}
+	for _, s := range hooks {
+		if err := s.binding.onStop(s.instance); err != nil {
+			c.log(fmt.Sprintf("di: stop hook failed for %s: %v", s.binding.typ, err))
+		}
+	}
+	c.dispose(instance)
+}
+
+// reset clears the binding's cached instances, including those of the bindings created from it
+// per name, and returns them.
+func (b *binding) reset() []any {
+	if b.origin != nil {
+		return b.origin.reset()
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	var instances []any
+	if cached := b.concrete.Swap(nil); cached != nil {
+		instances = append(instances, *cached)
+	}
+	b.children.Range(func(_, child any) bool {
+		instances = append(instances, child.(*binding).reset()...)
+		return true
+	})
+	return append(instances, b.keyed.clear()...)
+}
+
+// WithOnStart registers a hook that is called right after the singleton instance is constructed,
+// which is during Start for deferred eager bindings. If the hook fails, the resolution fails
+// and the instance is not cached. Bindings cached per key call it for the instance of every key.
+func WithOnStart(hook func(instance any) error) BindOption {
+	return func(config *bindConfig) {
+		config.onStart = hook
+	}
+}
+
+// WithOnStop registers a hook that Close calls for the singleton instance once it has been constructed.
+// Bindings cached per key call it for the instance of every key, or when the instance is evicted.
+func WithOnStop(hook func(instance any) error) BindOption {
+	return func(config *bindConfig) {
+		config.onStop = hook
+	}
+}
+
+// stopper is a constructed singleton instance whose stop hook has yet to run.
+type stopper struct {
+	binding  *binding
+	instance any
+}
+
+// startInstance runs the binding's start hook for a newly constructed singleton instance
+// and remembers the instance for Close if the binding has a stop hook.
+func (c *Container) startInstance(b *binding, instance any) error {
+	if b.onStart != nil {
+		if err := b.onStart(instance); err != nil {
+			return fmt.Errorf("start hook failed for %s: %w", b.typ, err)
+		}
+	}
+
+	_, startable := instance.(Startable)
+	_, stoppable := instance.(Stoppable)
+	if b.onStop != nil || startable || stoppable {
+		c.lock.Lock()
+		if b.onStop != nil {
+			c.stoppers = append(c.stoppers, stopper{binding: b, instance: instance})
+		}
+		if startable || stoppable {
+			c.services = append(c.services, instance)
+		}
+		c.lock.Unlock()
+	}
+	return nil
+}
+
+// Close calls the stop hooks of every constructed singleton in reverse construction order,
+// so instances are stopped before their dependencies. Every hook runs even if an earlier one fails;
+// their errors are joined. Each hook is called at most once.
+func (c *Container) Close() error {
+	c.lock.Lock()
+	stoppers := c.stoppers
+	c.stoppers = nil
+	c.lock.Unlock()
+
+	var errs []error
+	for i := len(stoppers) - 1; i >= 0; i-- {
+		s := stoppers[i]
+		if err := s.binding.onStop(s.instance); err != nil {
+			errs = append(errs, fmt.Errorf("stop hook failed for %s: %w", s.binding.typ, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,667 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer_Start(t *testing.T) {
+	t.Run("deferred eager bindings are built by Start", func(t *testing.T) {
+		container := New()
+		container.SetDeferEager(true)
+		constructed := 0
+
+		// Registered before its dependency, which would fail without deferral.
+		err := container.Bind(func(db Database) UserService {
+			constructed++
+			return &userServiceImpl{db: db}
+		}, WithEager())
+		require.NoError(t, err)
+
+		err = container.Bind(func() Database {
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 0, constructed)
+
+		require.NoError(t, container.Start(context.Background()))
+		assert.Equal(t, 1, constructed)
+
+		var userService UserService
+		require.NoError(t, container.Resolve(&userService))
+		assert.Equal(t, 1, constructed)
+	})
+
+	t.Run("Start reports the failing binding", func(t *testing.T) {
+		container := New()
+		container.SetDeferEager(true)
+
+		err := container.BindNamed("primary", func() (Database, error) {
+			return nil, errors.New("connection refused")
+		}, WithEager())
+		require.NoError(t, err)
+
+		err = container.Start(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "di.Database")
+		assert.Contains(t, err.Error(), "primary")
+		assert.Contains(t, err.Error(), "connection refused")
+	})
+
+	t.Run("Start skips lazy bindings", func(t *testing.T) {
+		container := New()
+		container.SetDeferEager(true)
+		constructed := false
+
+		err := container.Bind(func() Database {
+			constructed = true
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, container.Start(context.Background()))
+		assert.False(t, constructed)
+	})
+
+	t.Run("eager bindings are built during Bind by default", func(t *testing.T) {
+		container := New()
+
+		err := container.Bind(func(db Database) UserService {
+			return &userServiceImpl{db: db}
+		}, WithEager())
+		assert.Error(t, err)
+	})
+}
+
+func TestContainer_ResetSingleton(t *testing.T) {
+	t.Run("next resolve constructs a new instance", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func() *closableConnection {
+			return &closableConnection{dsn: "primary"}
+		})
+		require.NoError(t, err)
+
+		var first *closableConnection
+		require.NoError(t, container.Resolve(&first))
+
+		require.NoError(t, container.ResetSingleton(&first))
+		assert.True(t, first.closed)
+
+		var second *closableConnection
+		require.NoError(t, container.Resolve(&second))
+		assert.NotSame(t, first, second)
+		assert.False(t, second.closed)
+	})
+
+	t.Run("named binding", func(t *testing.T) {
+		container := New()
+		constructed := 0
+		err := container.BindNamed("replica", func() Database {
+			constructed++
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		var db Database
+		require.NoError(t, container.ResolveNamed(&db, "replica"))
+		require.NoError(t, container.ResetSingletonNamed(&db, "replica"))
+		require.NoError(t, container.ResolveNamed(&db, "replica"))
+		assert.Equal(t, 2, constructed)
+	})
+
+	t.Run("reset before first resolve", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func() Database {
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		var db Database
+		assert.NoError(t, container.ResetSingleton(&db))
+	})
+
+	t.Run("missing binding", func(t *testing.T) {
+		container := New()
+
+		var db Database
+		assert.ErrorIs(t, container.ResetSingleton(&db), ErrBindingNotFound)
+		assert.ErrorIs(t, container.ResetSingleton(db), ErrNotAPointer)
+	})
+}
+
+type appConfig struct {
+	version int
+}
+
+type configuredService struct {
+	config *appConfig
+}
+
+func TestContainer_WithReactive(t *testing.T) {
+	newContainer := func(t *testing.T, options ...BindOption) *Container {
+		container := New()
+		version := 0
+		err := container.Bind(func() *appConfig {
+			version++
+			return &appConfig{version: version}
+		})
+		require.NoError(t, err)
+		err = container.Bind(func(cfg *appConfig) *configuredService {
+			return &configuredService{config: cfg}
+		}, options...)
+		require.NoError(t, err)
+		return container
+	}
+
+	t.Run("reactive dependent rebuilds after its dependency is reset", func(t *testing.T) {
+		container := newContainer(t, WithReactive())
+
+		var first *configuredService
+		require.NoError(t, container.Resolve(&first))
+		assert.Equal(t, 1, first.config.version)
+
+		require.NoError(t, container.ResetSingleton(new(*appConfig)))
+
+		var second *configuredService
+		require.NoError(t, container.Resolve(&second))
+		assert.NotSame(t, first, second)
+		assert.Equal(t, 2, second.config.version)
+	})
+
+	t.Run("non-reactive dependent keeps its instance", func(t *testing.T) {
+		container := newContainer(t)
+
+		var first *configuredService
+		require.NoError(t, container.Resolve(&first))
+		require.NoError(t, container.ResetSingleton(new(*appConfig)))
+
+		var second *configuredService
+		require.NoError(t, container.Resolve(&second))
+		assert.Same(t, first, second)
+		assert.Equal(t, 1, second.config.version)
+	})
+
+	t.Run("resets propagate through reactive chains", func(t *testing.T) {
+		container := newContainer(t, WithReactive())
+		err := container.Bind(func(service *configuredService) UserService {
+			return &userServiceImpl{}
+		}, WithReactive())
+		require.NoError(t, err)
+
+		var first UserService
+		require.NoError(t, container.Resolve(&first))
+		require.NoError(t, container.ResetSingleton(new(*appConfig)))
+
+		var second UserService
+		require.NoError(t, container.Resolve(&second))
+		assert.NotSame(t, first, second)
+	})
+}
+
+func TestContainer_LifecycleHooks(t *testing.T) {
+	t.Run("start hook fires once per singleton", func(t *testing.T) {
+		container := New()
+		started := 0
+		err := container.Bind(func() Database {
+			return &mockDatabase{}
+		}, WithOnStart(func(instance any) error {
+			started++
+			return instance.(Database).Connect()
+		}))
+		require.NoError(t, err)
+
+		var db Database
+		require.NoError(t, container.Resolve(&db))
+		require.NoError(t, container.Resolve(&db))
+		assert.Equal(t, 1, started)
+		assert.True(t, db.(*mockDatabase).connected)
+	})
+
+	t.Run("hooks run for every keyed instance", func(t *testing.T) {
+		container := New()
+		var started, stopped []string
+		err := container.Bind(func(ctx context.Context) *closableConnection {
+			return &closableConnection{dsn: ctx.Value(tenantKey{}).(string)}
+		}, WithContextKey(tenantKey{}), WithMaxCacheSize(1), WithOnStart(func(instance any) error {
+			started = append(started, instance.(*closableConnection).dsn)
+			return nil
+		}), WithOnStop(func(instance any) error {
+			stopped = append(stopped, instance.(*closableConnection).dsn)
+			return nil
+		}))
+		require.NoError(t, err)
+
+		resolve := func(tenant string) {
+			var conn *closableConnection
+			ctx := context.WithValue(context.Background(), tenantKey{}, tenant)
+			require.NoError(t, container.ResolveContext(ctx, &conn))
+		}
+		resolve("acme")
+		resolve("acme")
+		assert.Equal(t, []string{"acme"}, started)
+
+		// Evicting acme stops it, and Close stops the instance still cached
+		resolve("globex")
+		assert.Equal(t, []string{"acme", "globex"}, started)
+		assert.Equal(t, []string{"acme"}, stopped)
+
+		require.NoError(t, container.Close())
+		assert.Equal(t, []string{"acme", "globex"}, stopped)
+	})
+
+	t.Run("failed start hook is returned and the instance is not cached", func(t *testing.T) {
+		container := New()
+		constructed := 0
+		fail := true
+		err := container.Bind(func() Database {
+			constructed++
+			return &mockDatabase{}
+		}, WithOnStart(func(instance any) error {
+			if fail {
+				return errors.New("connection refused")
+			}
+			return nil
+		}))
+		require.NoError(t, err)
+
+		var db Database
+		assert.EqualError(t, container.Resolve(&db), "start hook failed for di.Database: connection refused")
+
+		fail = false
+		require.NoError(t, container.Resolve(&db))
+		assert.Equal(t, 2, constructed)
+	})
+
+	t.Run("stop hooks fire in LIFO order with errors aggregated", func(t *testing.T) {
+		container := New()
+		var stopped []string
+		errDatabase := errors.New("database still busy")
+		errService := errors.New("service still busy")
+
+		err := container.Bind(func() Database {
+			return &mockDatabase{}
+		}, WithOnStop(func(instance any) error {
+			stopped = append(stopped, "database")
+			return errDatabase
+		}))
+		require.NoError(t, err)
+		err = container.Bind(func(db Database) UserService {
+			return &userServiceImpl{db: db}
+		}, WithOnStop(func(instance any) error {
+			stopped = append(stopped, "user service")
+			return errService
+		}))
+		require.NoError(t, err)
+		err = container.Bind(func() Logger {
+			return &loggerImpl{}
+		}, WithOnStop(func(instance any) error {
+			stopped = append(stopped, "logger")
+			return nil
+		}))
+		require.NoError(t, err)
+
+		var userService UserService
+		require.NoError(t, container.Resolve(&userService))
+		var logger Logger
+		require.NoError(t, container.Resolve(&logger))
+
+		err = container.Close()
+		assert.ErrorIs(t, err, errDatabase)
+		assert.ErrorIs(t, err, errService)
+		assert.Equal(t, []string{"logger", "user service", "database"}, stopped)
+
+		require.NoError(t, container.Close())
+		assert.Len(t, stopped, 3)
+	})
+
+	t.Run("deferred eager bindings start during Start", func(t *testing.T) {
+		container := New()
+		container.SetDeferEager(true)
+		started := false
+		err := container.Bind(func() Database {
+			return &mockDatabase{}
+		}, WithEager(), WithOnStart(func(instance any) error {
+			started = true
+			return nil
+		}))
+		require.NoError(t, err)
+		assert.False(t, started)
+
+		require.NoError(t, container.Start(context.Background()))
+		assert.True(t, started)
+	})
+
+	t.Run("unconstructed singletons are not stopped", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func() Database {
+			return &mockDatabase{}
+		}, WithOnStop(func(instance any) error {
+			t.Fatal("stop hook called for an instance that was never constructed")
+			return nil
+		}))
+		require.NoError(t, err)
+
+		assert.NoError(t, container.Close())
+	})
+}
+
+func TestContainer_WithEagerReset(t *testing.T) {
+	t.Run("constructed at bind and rebuilt lazily after a reset", func(t *testing.T) {
+		container := New()
+		constructed := 0
+		err := container.Bind(func() Database {
+			constructed++
+			return &mockDatabase{}
+		}, WithEager())
+		require.NoError(t, err)
+		assert.Equal(t, 1, constructed)
+
+		var first Database
+		require.NoError(t, container.Resolve(&first))
+		assert.Equal(t, 1, constructed)
+
+		require.NoError(t, container.ResetSingleton(&first))
+		assert.Equal(t, 1, constructed)
+
+		var second Database
+		require.NoError(t, container.Resolve(&second))
+		assert.Equal(t, 2, constructed)
+		assert.NotSame(t, first, second)
+	})
+
+	t.Run("initialization errors surface at bind", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func() (Database, error) {
+			return nil, errors.New("connection refused")
+		}, WithEager())
+		assert.EqualError(t, err, "eager construction of di.Database failed: connection refused")
+	})
+}
+
+type lifecycleLog struct {
+	events []string
+}
+
+type store struct {
+	log *lifecycleLog
+}
+
+func (s *store) Start(ctx context.Context) error {
+	s.log.events = append(s.log.events, "start store")
+	return nil
+}
+
+func (s *store) Stop(ctx context.Context) error {
+	s.log.events = append(s.log.events, "stop store")
+	return nil
+}
+
+type server struct {
+	store    *store
+	log      *lifecycleLog
+	startErr error
+}
+
+func (s *server) Start(ctx context.Context) error {
+	if s.startErr != nil {
+		return s.startErr
+	}
+	s.log.events = append(s.log.events, "start server")
+	return nil
+}
+
+func (s *server) Stop(ctx context.Context) error {
+	s.log.events = append(s.log.events, "stop server")
+	return nil
+}
+
+func TestContainer_StartStop(t *testing.T) {
+	t.Run("services start in dependency order and stop in reverse", func(t *testing.T) {
+		container := New()
+		container.SetDeferEager(true)
+		log := &lifecycleLog{}
+
+		err := container.Bind(func(s *store) *server {
+			return &server{store: s, log: log}
+		}, WithEager())
+		require.NoError(t, err)
+		err = container.Bind(func() *store {
+			return &store{log: log}
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, container.Start(context.Background()))
+		assert.Equal(t, []string{"start store", "start server"}, log.events)
+
+		// Already started services are not started twice
+		require.NoError(t, container.Start(context.Background()))
+		assert.Len(t, log.events, 2)
+
+		require.NoError(t, container.Stop(context.Background()))
+		assert.Equal(t, []string{"start store", "start server", "stop server", "stop store"}, log.events)
+	})
+
+	t.Run("a failing Start aborts startup", func(t *testing.T) {
+		container := New()
+		container.SetDeferEager(true)
+		log := &lifecycleLog{}
+
+		err := container.Bind(func(s *store) *server {
+			return &server{store: s, log: log, startErr: errors.New("port in use")}
+		}, WithEager())
+		require.NoError(t, err)
+		err = container.Bind(func() *store {
+			return &store{log: log}
+		})
+		require.NoError(t, err)
+
+		err = container.Start(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "*di.server")
+		assert.Contains(t, err.Error(), "port in use")
+
+		// Only the services that started are stopped
+		require.NoError(t, container.Stop(context.Background()))
+		assert.Equal(t, []string{"start store", "stop store"}, log.events)
+	})
+
+	t.Run("reset services are stopped and forgotten", func(t *testing.T) {
+		container := New()
+		log := &lifecycleLog{}
+		hooks := 0
+		err := container.Bind(func() *store {
+			return &store{log: log}
+		}, WithOnStop(func(any) error {
+			hooks++
+			return nil
+		}))
+		require.NoError(t, err)
+
+		var first *store
+		require.NoError(t, container.Resolve(&first))
+		require.NoError(t, container.Start(context.Background()))
+
+		require.NoError(t, container.ResetSingleton(&first))
+		assert.Equal(t, []string{"start store", "stop store"}, log.events)
+		assert.Equal(t, 1, hooks)
+
+		// The new instance is the only one Start, Stop and Close reach
+		var second *store
+		require.NoError(t, container.Resolve(&second))
+		require.NoError(t, container.Start(context.Background()))
+		require.NoError(t, container.Stop(context.Background()))
+		require.NoError(t, container.Close())
+		assert.Equal(t, []string{"start store", "stop store", "start store", "stop store"}, log.events)
+		assert.Equal(t, 2, hooks)
+	})
+
+	t.Run("cleared services are forgotten", func(t *testing.T) {
+		for name, clear := range map[string]func(c *Container){
+			"Clear":         func(c *Container) { c.Clear() },
+			"ClearAndClose": func(c *Container) { require.NoError(t, c.ClearAndClose()) },
+		} {
+			t.Run(name, func(t *testing.T) {
+				container := New()
+				log := &lifecycleLog{}
+				hooks := 0
+				err := container.Bind(func() *store {
+					return &store{log: log}
+				}, WithOnStop(func(any) error {
+					hooks++
+					return nil
+				}))
+				require.NoError(t, err)
+
+				var s *store
+				require.NoError(t, container.Resolve(&s))
+				require.NoError(t, container.Start(context.Background()))
+				clear(container)
+
+				require.NoError(t, container.Stop(context.Background()))
+				require.NoError(t, container.Close())
+				assert.Equal(t, []string{"start store"}, log.events)
+				assert.Zero(t, hooks)
+			})
+		}
+	})
+}
+
+type Service interface {
+	Start() error
+	Stop() error
+}
+
+type recordingService struct {
+	name     string
+	log      *lifecycleLog
+	startErr error
+}
+
+func (s *recordingService) Start() error {
+	if s.startErr != nil {
+		return s.startErr
+	}
+	s.log.events = append(s.log.events, "start "+s.name)
+	return nil
+}
+
+func (s *recordingService) Stop() error {
+	s.log.events = append(s.log.events, "stop "+s.name)
+	return nil
+}
+
+func TestContainer_StartAll(t *testing.T) {
+	bindServices := func(t *testing.T, log *lifecycleLog, failing error) *Container {
+		container := New()
+		for _, name := range []string{"db", "cache", "http"} {
+			service := &recordingService{name: name, log: log}
+			if name == "http" {
+				service.startErr = failing
+			}
+			err := container.BindNamed(name, func() Service { return service })
+			require.NoError(t, err)
+		}
+		return container
+	}
+
+	t.Run("starts every service in order", func(t *testing.T) {
+		log := &lifecycleLog{}
+		container := bindServices(t, log, nil)
+
+		require.NoError(t, container.StartAll(new(Service)))
+		assert.Equal(t, []string{"start db", "start cache", "start http"}, log.events)
+	})
+
+	t.Run("failure stops the started services in reverse", func(t *testing.T) {
+		log := &lifecycleLog{}
+		container := bindServices(t, log, errors.New("port in use"))
+
+		err := container.StartAll(new(Service))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "port in use")
+		assert.Equal(t, []string{"start db", "start cache", "stop cache", "stop db"}, log.events)
+	})
+
+	t.Run("instances without a Start method", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func() Database { return &mockDatabase{} })
+		require.NoError(t, err)
+
+		err = container.StartAll(new(Database))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no Start() error method")
+	})
+}
+
+func TestWithBestEffort(t *testing.T) {
+	t.Run("failing eager binding is skipped and stays resolvable", func(t *testing.T) {
+		container := New()
+		logger := &recordingLogger{}
+		container.SetLogger(logger)
+		attempts := 0
+
+		err := container.BindNamed("metrics", func() (Database, error) {
+			attempts++
+			if attempts == 1 {
+				return nil, errors.New("metrics backend unavailable")
+			}
+			return &mockDatabase{}, nil
+		}, WithEager(), WithBestEffort())
+		require.NoError(t, err)
+		err = container.Bind(func() Database { return &mockDatabase{} }, WithEager())
+		require.NoError(t, err)
+
+		require.Len(t, logger.messages, 1)
+		assert.Contains(t, logger.messages[0], "metrics backend unavailable")
+
+		var db Database
+		require.NoError(t, container.ResolveNamed(&db, "metrics"))
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("Start continues past failing best-effort bindings", func(t *testing.T) {
+		container := New()
+		container.SetLogger(&recordingLogger{})
+		container.SetDeferEager(true)
+		constructed := false
+
+		err := container.BindNamed("metrics", func() (Database, error) {
+			return nil, errors.New("metrics backend unavailable")
+		}, WithEager(), WithBestEffort())
+		require.NoError(t, err)
+		err = container.Bind(func() Database {
+			constructed = true
+			return &mockDatabase{}
+		}, WithEager())
+		require.NoError(t, err)
+
+		require.NoError(t, container.Start(context.Background()))
+		assert.True(t, constructed)
+
+		var db Database
+		assert.ErrorContains(t, container.ResolveNamed(&db, "metrics"), "metrics backend unavailable")
+	})
+
+	t.Run("StartAll skips best-effort services that fail to start", func(t *testing.T) {
+		container := New()
+		container.SetLogger(&recordingLogger{})
+		log := &lifecycleLog{}
+
+		for _, name := range []string{"db", "metrics", "http"} {
+			service := &recordingService{name: name, log: log}
+			var options []BindOption
+			if name == "metrics" {
+				service.startErr = errors.New("metrics backend unavailable")
+				options = append(options, WithBestEffort())
+			}
+			require.NoError(t, container.BindNamed(name, func() Service { return service }, options...))
+		}
+
+		require.NoError(t, container.StartAll(new(Service)))
+		assert.Equal(t, []string{"start db", "start http"}, log.events)
+	})
+}
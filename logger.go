@@ -0,0 +1,34 @@
+package di
+
+import (
+	"context"
+	"log"
+	"reflect"
+)
+
+// Logger receives diagnostic messages from the container, such as failures
+// that were recovered from by a fail-safe binding.
+type Logger interface {
+	Log(message string)
+}
+
+// stdLogger forwards messages to the standard library logger.
+type stdLogger struct{}
+
+func (stdLogger) Log(message string) {
+	log.Print(message)
+}
+
+var loggerType = reflect.TypeOf((*Logger)(nil)).Elem()
+
+// ResolveWithLogger resolves the target while injecting logger into every factory that requests a Logger.
+// The override applies to this resolution only: the Logger binding is left untouched, and singletons
+// that receive the logger are constructed for this call without being cached. Singletons that are
+// already cached are returned as they are.
+func (c *Container) ResolveWithLogger(target interface{}, logger Logger) error {
+	r := c.begin(context.Background())
+	r.overrides = map[reflect.Type]reflect.Value{
+		loggerType: valueOf(logger, loggerType),
+	}
+	return c.resolveNamed(r, target, "", lifetimeDefault)
+}
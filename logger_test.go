@@ -0,0 +1,76 @@
+package di
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type requestHandler struct {
+	logger Logger
+}
+
+func TestContainer_ResolveWithLogger(t *testing.T) {
+	newContainer := func(t *testing.T, defaultLogger Logger, options ...BindOption) *Container {
+		container := New()
+
+		err := container.Bind(func() Logger {
+			return defaultLogger
+		})
+		require.NoError(t, err)
+
+		err = container.Bind(func(logger Logger) *requestHandler {
+			return &requestHandler{logger: logger}
+		}, options...)
+		require.NoError(t, err)
+
+		return container
+	}
+
+	t.Run("factory receives the per-call logger", func(t *testing.T) {
+		defaultLogger := &loggerImpl{}
+		requestLogger := &loggerImpl{}
+		container := newContainer(t, defaultLogger, WithTransient())
+
+		var handler *requestHandler
+		err := container.ResolveWithLogger(&handler, requestLogger)
+		require.NoError(t, err)
+		assert.Same(t, requestLogger, handler.logger)
+
+		err = container.Resolve(&handler)
+		require.NoError(t, err)
+		assert.Same(t, defaultLogger, handler.logger)
+	})
+
+	t.Run("logger binding is not replaced", func(t *testing.T) {
+		defaultLogger := &loggerImpl{}
+		container := newContainer(t, defaultLogger)
+
+		var handler *requestHandler
+		err := container.ResolveWithLogger(&handler, &loggerImpl{})
+		require.NoError(t, err)
+
+		var logger Logger
+		err = container.Resolve(&logger)
+		require.NoError(t, err)
+		assert.Same(t, defaultLogger, logger)
+	})
+
+	t.Run("singletons built with the per-call logger are not cached", func(t *testing.T) {
+		defaultLogger := &loggerImpl{}
+		requestLogger := &loggerImpl{}
+		container := newContainer(t, defaultLogger)
+
+		var scoped *requestHandler
+		err := container.ResolveWithLogger(&scoped, requestLogger)
+		require.NoError(t, err)
+		assert.Same(t, requestLogger, scoped.logger)
+
+		var handler *requestHandler
+		err = container.Resolve(&handler)
+		require.NoError(t, err)
+		assert.Same(t, defaultLogger, handler.logger)
+		assert.NotSame(t, scoped, handler)
+	})
+}
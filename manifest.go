@@ -0,0 +1,112 @@
+package di
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// manifestEntry is the serialized definition of a binding, see ExportBindings.
+type manifestEntry struct {
+	Type      string            `json:"type"`
+	Name      string            `json:"name,omitempty"`
+	Singleton bool              `json:"singleton"`
+	Group     string            `json:"group,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Tags      []string          `json:"tags,omitempty"`
+}
+
+// RegisterFactory makes a factory available to ImportBindings under its result type and the given name,
+// without registering a binding. The factory must produce a single type, optionally with an error.
+func (c *Container) RegisterFactory(name string, factory interface{}) error {
+	factoryType := reflect.TypeOf(factory)
+	if factoryType == nil || factoryType.Kind() != reflect.Func {
+		return fmt.Errorf("container: the factory %w", ErrNotAFunction)
+	}
+	if err := c.validateResolverFunction(factoryType); err != nil {
+		return err
+	}
+	resolveTypes := resultTypes(factoryType)
+	if len(resolveTypes) != 1 {
+		return fmt.Errorf("factory must produce a single type, got %d", len(resolveTypes))
+	}
+
+	typeName := resolveTypes[0].String()
+	c.configure(func(r *registry) {
+		if r.factories == nil {
+			r.factories = make(map[string]map[string]interface{})
+		}
+		if r.factories[typeName] == nil {
+			r.factories[typeName] = make(map[string]interface{})
+		}
+		r.factories[typeName][name] = factory
+	})
+	return nil
+}
+
+// ExportBindings serializes the definitions of the registered bindings, not their instances, as a JSON
+// manifest of types, names, lifetimes, groups, labels and tags in registration order.
+func (c *Container) ExportBindings() ([]byte, error) {
+	c.lock.RLock()
+	bindings := c.allBindings()
+	c.lock.RUnlock()
+
+	entries := make([]manifestEntry, 0, len(bindings))
+	for _, b := range bindings {
+		name := b.name
+		if b.group != "" && name == fmt.Sprintf("%s#%d", b.group, b.seq) {
+			name = ""
+		}
+		entries = append(entries, manifestEntry{
+			Type:      b.typ.String(),
+			Name:      name,
+			Singleton: b.singleton,
+			Group:     b.group,
+			Labels:    b.labels,
+			Tags:      b.tags,
+		})
+	}
+	return json.Marshal(entries)
+}
+
+// ImportBindings registers a lazy binding for every definition of a manifest produced by ExportBindings,
+// using the factory registered with RegisterFactory for its type and name. Group members that were
+// registered without a name use the factory registered under their group name. Nothing is registered
+// if the manifest is invalid or a factory is missing.
+func (c *Container) ImportBindings(data []byte) error {
+	var entries []manifestEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("invalid binding manifest: %w", err)
+	}
+
+	c.lock.RLock()
+	factories := make([]interface{}, len(entries))
+	for i, entry := range entries {
+		key := entry.Name
+		if key == "" && entry.Group != "" {
+			key = entry.Group
+		}
+		factories[i] = c.factories[entry.Type][key]
+	}
+	c.lock.RUnlock()
+
+	return c.Transaction(func(tx *Container) error {
+		for i, entry := range entries {
+			if factories[i] == nil {
+				return fmt.Errorf("no factory registered for type %s with name '%s'", entry.Type, entry.Name)
+			}
+
+			options := []BindOption{WithName(entry.Name), WithGroup(entry.Group), WithTags(entry.Tags...)}
+			if !entry.Singleton {
+				options = append(options, WithTransient())
+			}
+			for key, value := range entry.Labels {
+				options = append(options, WithLabel(key, value))
+			}
+			if err := tx.Bind(factories[i], options...); err != nil {
+				return fmt.Errorf("importing binding for type %s with name '%s': %w", entry.Type, entry.Name, err)
+			}
+		}
+		return nil
+	})
+}
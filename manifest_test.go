@@ -0,0 +1,63 @@
+package di
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer_ExportImportBindings(t *testing.T) {
+	registerFactories := func(t *testing.T, container *Container) {
+		require.NoError(t, container.RegisterFactory("", func() Database { return &mockDatabase{} }))
+		require.NoError(t, container.RegisterFactory("replica", func() Database { return &mockDatabase{} }))
+		require.NoError(t, container.RegisterFactory("services", func(db Database) UserService {
+			return &userServiceImpl{db: db}
+		}))
+	}
+
+	t.Run("round trip reconstructs the binding metadata", func(t *testing.T) {
+		source := New()
+		require.NoError(t, source.Bind(func() Database { return &mockDatabase{} }, WithLabel("tier", "storage")))
+		require.NoError(t, source.BindNamed("replica", func() Database { return &mockDatabase{} }, WithTransient(), WithTags("read")))
+		require.NoError(t, source.Bind(func(db Database) UserService {
+			return &userServiceImpl{db: db}
+		}, WithGroup("services")))
+
+		manifest, err := source.ExportBindings()
+		require.NoError(t, err)
+
+		target := New()
+		registerFactories(t, target)
+		assert.Empty(t, target.Bindings())
+		require.NoError(t, target.ImportBindings(manifest))
+
+		assert.Equal(t, source.Bindings(), target.Bindings())
+		services, err := ResolveGroup[UserService](target, "services")
+		require.NoError(t, err)
+		assert.Len(t, services, 1)
+	})
+
+	t.Run("missing factory registers nothing", func(t *testing.T) {
+		source := New()
+		require.NoError(t, source.Bind(func() Database { return &mockDatabase{} }))
+		require.NoError(t, source.Bind(func() Logger { return &loggerImpl{} }))
+		manifest, err := source.ExportBindings()
+		require.NoError(t, err)
+
+		target := New()
+		registerFactories(t, target)
+		err = target.ImportBindings(manifest)
+		assert.EqualError(t, err, "no factory registered for type di.Logger with name ''")
+		assert.Empty(t, target.Bindings())
+	})
+
+	t.Run("invalid manifest", func(t *testing.T) {
+		assert.ErrorContains(t, New().ImportBindings([]byte("not json")), "invalid binding manifest")
+	})
+
+	t.Run("factory must produce a single type", func(t *testing.T) {
+		err := New().RegisterFactory("", func() (Database, Logger) { return nil, nil })
+		assert.EqualError(t, err, "factory must produce a single type, got 2")
+	})
+}
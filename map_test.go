@@ -0,0 +1,92 @@
+package di
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type PaymentProcessor interface {
+	Charge(amount int) string
+}
+
+type paymentProcessor struct {
+	provider string
+}
+
+func (p *paymentProcessor) Charge(amount int) string {
+	return p.provider
+}
+
+type Checkout struct {
+	processors map[string]PaymentProcessor
+}
+
+func TestNamedMapInjection(t *testing.T) {
+	t.Run("map receives every binding by name", func(t *testing.T) {
+		container := New()
+		require.NoError(t, container.BindNamed("stripe", func() PaymentProcessor {
+			return &paymentProcessor{provider: "stripe"}
+		}))
+		require.NoError(t, container.BindNamed("paypal", func() PaymentProcessor {
+			return &paymentProcessor{provider: "paypal"}
+		}))
+		err := container.Bind(func(procs map[string]PaymentProcessor) *Checkout {
+			return &Checkout{processors: procs}
+		})
+		require.NoError(t, err)
+
+		var checkout *Checkout
+		require.NoError(t, container.Resolve(&checkout))
+		require.Len(t, checkout.processors, 2)
+		assert.Equal(t, "stripe", checkout.processors["stripe"].Charge(10))
+		assert.Equal(t, "paypal", checkout.processors["paypal"].Charge(10))
+
+		var stripe PaymentProcessor
+		require.NoError(t, container.ResolveNamed(&stripe, "stripe"))
+		assert.Same(t, stripe, checkout.processors["stripe"])
+		assert.NoError(t, container.Validate())
+	})
+
+	t.Run("default binding is keyed by the empty name", func(t *testing.T) {
+		container := New()
+		require.NoError(t, container.Bind(func() PaymentProcessor {
+			return &paymentProcessor{provider: "default"}
+		}))
+		require.NoError(t, container.Bind(func(procs map[string]PaymentProcessor) *Checkout {
+			return &Checkout{processors: procs}
+		}))
+
+		var checkout *Checkout
+		require.NoError(t, container.Resolve(&checkout))
+		assert.Equal(t, "default", checkout.processors[""].Charge(10))
+	})
+
+	t.Run("map binding takes precedence", func(t *testing.T) {
+		container := New()
+		require.NoError(t, container.BindNamed("stripe", func() PaymentProcessor {
+			return &paymentProcessor{provider: "stripe"}
+		}))
+		require.NoError(t, container.Bind(func() map[string]PaymentProcessor {
+			return map[string]PaymentProcessor{}
+		}))
+		require.NoError(t, container.Bind(func(procs map[string]PaymentProcessor) *Checkout {
+			return &Checkout{processors: procs}
+		}))
+
+		var checkout *Checkout
+		require.NoError(t, container.Resolve(&checkout))
+		assert.Empty(t, checkout.processors)
+	})
+
+	t.Run("error without bindings", func(t *testing.T) {
+		container := New()
+		require.NoError(t, container.Bind(func(procs map[string]PaymentProcessor) *Checkout {
+			return &Checkout{processors: procs}
+		}))
+
+		var checkout *Checkout
+		assert.ErrorIs(t, container.Resolve(&checkout), ErrBindingNotFound)
+	})
+}
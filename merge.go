@@ -0,0 +1,122 @@
+package di
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// MergeOption represents a configuration option for Merge.
+type MergeOption func(*mergeConfig)
+
+type mergeConfig struct {
+	override bool
+}
+
+// WithOverride makes Merge replace bindings that exist in both containers instead of failing.
+func WithOverride() MergeOption {
+	return func(config *mergeConfig) {
+		config.override = true
+	}
+}
+
+// Merge copies the bindings of other into the container. The copies share the instances of the bindings of other,
+// including their cached singletons, so an instance constructed through either container is reused by the other.
+// They are ordered after the bindings already registered in the container, and group members without an explicit
+// name get a name generated from their new position. If a type and name is bound in both containers, Merge fails
+// without copying anything, unless WithOverride is given.
+func (c *Container) Merge(other *Container, options ...MergeOption) error {
+	config := &mergeConfig{}
+	for _, option := range options {
+		option(config)
+	}
+
+	type entry struct {
+		typ  reflect.Type
+		head *binding
+	}
+	var entries []entry
+	var sources []*binding
+	other.lock.RLock()
+	for t, bindings := range other.bindings {
+		for _, head := range bindings {
+			entries = append(entries, entry{typ: t, head: head})
+			for b := head; b != nil; b = b.next {
+				sources = append(sources, b)
+			}
+		}
+	}
+	other.lock.RUnlock()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// Number the copies after the container's bindings, in the order they were registered in other
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i].before(sources[j])
+	})
+	seqs := make(map[*binding]uint64, len(sources))
+	for i, b := range sources {
+		seqs[b] = c.seq + uint64(i) + 1
+	}
+
+	merged := make(map[reflect.Type]map[string]*binding)
+	for _, e := range entries {
+		name := e.head.name
+		if e.head.group != "" && name == fmt.Sprintf("%s#%d", e.head.group, e.head.seq) {
+			name = fmt.Sprintf("%s#%d", e.head.group, seqs[e.head])
+		}
+
+		var head, last *binding
+		for b := e.head; b != nil; b = b.next {
+			copied := b.share(name, seqs[b])
+			if head == nil {
+				head = copied
+			} else {
+				last.next = copied
+			}
+			last = copied
+		}
+		if _, exists := merged[e.typ]; !exists {
+			merged[e.typ] = make(map[string]*binding)
+		}
+		merged[e.typ][name] = head
+	}
+
+	if !config.override {
+		var errs []error
+		for t, bindings := range merged {
+			for name := range bindings {
+				if _, exists := c.bindings[t][name]; exists {
+					errs = append(errs, fmt.Errorf("conflicting bindings for type %s with name '%s'", t, name))
+				}
+			}
+		}
+		if len(errs) > 0 {
+			return errors.Join(errs...)
+		}
+	}
+
+	for t, bindings := range merged {
+		if _, exists := c.bindings[t]; !exists {
+			c.bindings[t] = make(map[string]*binding)
+		}
+		for name, b := range bindings {
+			c.bindings[t][name] = b
+		}
+	}
+
+	// Bindings registered after the merge are ordered after the merged ones
+	c.seq += uint64(len(sources))
+	return nil
+}
+
+// share returns a copy of the binding with the given name and sequence number, which shares the binding's instances.
+func (b *binding) share(name string, seq uint64) *binding {
+	copied := b.config.newBinding(b.typ, name, seq, b.resolver, b.out, nil)
+	copied.template = b.template
+	copied.eager = b.eager
+	copied.origin = b.state()
+	return copied
+}
@@ -0,0 +1,98 @@
+package di
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer_Merge(t *testing.T) {
+	t.Run("disjoint bindings", func(t *testing.T) {
+		storage := New()
+		require.NoError(t, storage.Bind(func() Database { return &mockDatabase{} }))
+		services := New()
+		require.NoError(t, services.Bind(func(db Database) UserService {
+			return &userServiceImpl{db: db}
+		}))
+
+		app := New()
+		require.NoError(t, app.Merge(storage))
+		require.NoError(t, app.Merge(services))
+
+		var userService UserService
+		require.NoError(t, app.Resolve(&userService))
+
+		// Singletons are shared with the source container
+		var db, fromStorage Database
+		require.NoError(t, app.Resolve(&db))
+		require.NoError(t, storage.Resolve(&fromStorage))
+		assert.Same(t, fromStorage, db)
+		assert.Same(t, db, userService.(*userServiceImpl).db)
+	})
+
+	t.Run("conflicting binding", func(t *testing.T) {
+		app := New()
+		original := &mockDatabase{}
+		require.NoError(t, app.Bind(func() Database { return original }))
+
+		other := New()
+		replacement := &mockDatabase{}
+		require.NoError(t, other.Bind(func() Database { return replacement }))
+		require.NoError(t, other.Bind(func() Logger { return &loggerImpl{} }))
+
+		err := app.Merge(other)
+		assert.EqualError(t, err, "conflicting bindings for type di.Database with name ''")
+
+		// Nothing is merged when there is a conflict
+		var logger Logger
+		assert.ErrorIs(t, app.Resolve(&logger), ErrBindingNotFound)
+
+		require.NoError(t, app.Merge(other, WithOverride()))
+		var db Database
+		require.NoError(t, app.Resolve(&db))
+		assert.Same(t, replacement, db)
+		assert.NoError(t, app.Resolve(&logger))
+	})
+
+	t.Run("merged bindings are ordered after the container's", func(t *testing.T) {
+		connect := func(dsn string) func() *closableConnection {
+			return func() *closableConnection { return &closableConnection{dsn: dsn} }
+		}
+		app := New()
+		require.NoError(t, app.Bind(connect("primary"), WithGroup("dbs")))
+
+		other := New()
+		require.NoError(t, other.Bind(connect("replica"), WithGroup("dbs")))
+		require.NoError(t, other.Bind(func() Logger { return &loggerImpl{} }))
+
+		// Both containers generated the name dbs#1, which must not conflict
+		require.NoError(t, app.Merge(other))
+		require.NoError(t, app.Bind(connect("archive"), WithGroup("dbs")))
+
+		dsns := func(c *Container) []string {
+			conns, err := ResolveGroup[*closableConnection](c, "dbs")
+			require.NoError(t, err)
+			var dsns []string
+			for _, conn := range conns {
+				dsns = append(dsns, conn.dsn)
+			}
+			return dsns
+		}
+		assert.Equal(t, []string{"primary", "replica", "archive"}, dsns(app))
+
+		var names []string
+		for _, info := range app.Bindings() {
+			names = append(names, info.Type.String()+" "+info.Name)
+		}
+		assert.Equal(t, []string{
+			"*di.closableConnection dbs#1",
+			"*di.closableConnection dbs#2",
+			"di.Logger ",
+			"*di.closableConnection dbs#4",
+		}, names)
+
+		// The source container keeps its own names and order
+		assert.Equal(t, []string{"replica"}, dsns(other))
+	})
+}
@@ -0,0 +1,50 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// MissingHandler supplies an instance of type t for the name when no binding exists, see OnMissing.
+// It returns nil to leave the binding missing.
+type MissingHandler func(t reflect.Type, name string) (interface{}, error)
+
+// OnMissing sets the handler asked for an instance whenever a resolution, or a dependency of one,
+// finds no binding, e.g. to construct a default logger on demand. A supplied instance is registered
+// as a singleton binding for the type and name, so later resolutions reuse it without asking again.
+// Pass nil to remove the handler.
+func (c *Container) OnMissing(handler MissingHandler) {
+	c.configure(func(r *registry) {
+		r.onMissing = handler
+	})
+}
+
+// supply asks the missing handler for an instance of t and registers it. It reports false if there is
+// no handler or the handler supplied nothing.
+func (c *Container) supply(t reflect.Type, name string) (reflect.Value, bool, error) {
+	c.lock.RLock()
+	handler := c.onMissing
+	c.lock.RUnlock()
+
+	if handler == nil {
+		return reflect.Value{}, false, nil
+	}
+
+	instance, err := handler(t, name)
+	if err != nil {
+		return reflect.Value{}, false, fmt.Errorf("missing handler failed for %s with name '%s': %w", t, name, err)
+	}
+	if instance == nil {
+		return reflect.Value{}, false, nil
+	}
+	if !reflect.TypeOf(instance).AssignableTo(t) {
+		return reflect.Value{}, false, fmt.Errorf("missing handler supplied %T, which is not assignable to %s", instance, t)
+	}
+
+	value := reflect.New(t).Elem()
+	value.Set(reflect.ValueOf(instance))
+	if err := c.bind(constant(value), &bindConfig{name: name, singleton: true, lazy: true}); err != nil {
+		return reflect.Value{}, false, err
+	}
+	return value, true, nil
+}
@@ -0,0 +1,75 @@
+package di
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer_OnMissing(t *testing.T) {
+	t.Run("supplied instance is cached", func(t *testing.T) {
+		container := New()
+		calls := 0
+		container.OnMissing(func(t reflect.Type, name string) (interface{}, error) {
+			calls++
+			if t == loggerType {
+				return &loggerImpl{}, nil
+			}
+			return nil, nil
+		})
+
+		var first, second Logger
+		require.NoError(t, container.Resolve(&first))
+		require.NoError(t, container.Resolve(&second))
+		assert.Same(t, first, second)
+		assert.Equal(t, 1, calls)
+
+		// Unsupported types are still missing
+		var db Database
+		assert.ErrorIs(t, container.Resolve(&db), ErrBindingNotFound)
+	})
+
+	t.Run("supplies dependencies", func(t *testing.T) {
+		container := New()
+		container.OnMissing(func(t reflect.Type, name string) (interface{}, error) {
+			return &loggerImpl{messages: []string{name}}, nil
+		})
+		err := container.Bind(func(logger Logger) UserService {
+			logger.Log("constructed")
+			return &userServiceImpl{}
+		})
+		require.NoError(t, err)
+
+		var userService UserService
+		require.NoError(t, container.Resolve(&userService))
+
+		var logger Logger
+		require.NoError(t, container.Resolve(&logger))
+		assert.Equal(t, []string{"", "constructed"}, logger.(*loggerImpl).messages)
+	})
+
+	t.Run("handler errors", func(t *testing.T) {
+		container := New()
+		container.OnMissing(func(t reflect.Type, name string) (interface{}, error) {
+			return nil, errors.New("registry unavailable")
+		})
+
+		var logger Logger
+		err := container.ResolveNamed(&logger, "audit")
+		assert.EqualError(t, err, "missing handler failed for di.Logger with name 'audit': registry unavailable")
+	})
+
+	t.Run("instance of the wrong type", func(t *testing.T) {
+		container := New()
+		container.OnMissing(func(t reflect.Type, name string) (interface{}, error) {
+			return "not a logger", nil
+		})
+
+		var logger Logger
+		err := container.Resolve(&logger)
+		assert.EqualError(t, err, "missing handler supplied string, which is not assignable to di.Logger")
+	})
+}
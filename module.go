@@ -0,0 +1,35 @@
+package di
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Module groups related registrations, typically one per package, so wiring stays next to the code it wires.
+type Module interface {
+	Register(c *Container) error
+}
+
+// Install registers the modules in order, stopping at the first one that fails. Bindings registered
+// by the modules before the failure are kept.
+func (c *Container) Install(modules ...Module) error {
+	for _, module := range modules {
+		if err := module.Register(c); err != nil {
+			return fmt.Errorf("installing module %T: %w", module, err)
+		}
+	}
+	return nil
+}
+
+// Provide binds each factory with the default options, e.g. c.Provide(NewDB, NewLogger, NewUserService).
+// Bindings are lazy, so the factories may be listed in any order. Every factory is attempted; the
+// returned error joins the failures, identifying each failed factory by its position and type.
+func (c *Container) Provide(providers ...interface{}) error {
+	var errs []error
+	for i, provider := range providers {
+		if err := c.Bind(provider); err != nil {
+			errs = append(errs, fmt.Errorf("provider %d (%T): %w", i, provider, err))
+		}
+	}
+	return errors.Join(errs...)
+}
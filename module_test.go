@@ -0,0 +1,66 @@
+package di
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type storageModule struct{}
+
+func (storageModule) Register(c *Container) error {
+	return c.Bind(func() Database { return &mockDatabase{} })
+}
+
+type userModule struct{}
+
+func (userModule) Register(c *Container) error {
+	return c.Bind(func(db Database) UserService {
+		return &userServiceImpl{db: db}
+	})
+}
+
+type failingModule struct{}
+
+func (failingModule) Register(c *Container) error {
+	return errors.New("missing configuration")
+}
+
+func TestContainer_Install(t *testing.T) {
+	t.Run("modules depending on each other", func(t *testing.T) {
+		container := New()
+		require.NoError(t, container.Install(storageModule{}, userModule{}))
+
+		var userService UserService
+		require.NoError(t, container.Resolve(&userService))
+		assert.NotNil(t, userService.(*userServiceImpl).db)
+	})
+
+	t.Run("aborts on the first failing module", func(t *testing.T) {
+		container := New()
+		err := container.Install(storageModule{}, failingModule{}, userModule{})
+		assert.EqualError(t, err, "installing module di.failingModule: missing configuration")
+
+		var db Database
+		assert.NoError(t, container.Resolve(&db))
+		var userService UserService
+		assert.ErrorIs(t, container.Resolve(&userService), ErrBindingNotFound)
+	})
+}
+
+func TestContainer_Provide(t *testing.T) {
+	newUserService := func(db Database) UserService { return &userServiceImpl{db: db} }
+	newDatabase := func() Database { return &mockDatabase{} }
+	invalid := func() {}
+
+	container := New()
+	err := container.Provide(newUserService, invalid, newDatabase)
+	require.Error(t, err)
+	assert.Equal(t, "provider 1 (func()): need at least one return value besides an optional error", err.Error())
+
+	var userService UserService
+	require.NoError(t, container.Resolve(&userService))
+	assert.NotNil(t, userService.(*userServiceImpl).db)
+}
@@ -0,0 +1,58 @@
+package di
+
+import "reflect"
+
+// WithNameAware makes the factory receive the name it is resolved under as its first parameter,
+// which must be a string, e.g. func(name string) Cache. The binding answers every name of its type
+// that has no binding of its own, so ResolveNamed(&cache, "redis") calls the factory with "redis".
+// Singletons are cached per name. Name-aware bindings are always lazy.
+func WithNameAware() BindOption {
+	return func(config *bindConfig) {
+		config.nameAware = true
+	}
+}
+
+// forName returns the binding resolving the name-aware binding under name, creating it on first use.
+// Copies made by Merge share the bindings of the binding they were copied from.
+func (b *binding) forName(name string) *binding {
+	if b.origin != nil {
+		return b.origin.forName(name)
+	}
+	if child, exists := b.children.Load(name); exists {
+		return child.(*binding)
+	}
+
+	config := *b.template
+	if len(config.params) > 0 {
+		config.params = config.params[1:]
+	}
+	child := config.newBinding(b.typ, name, b.seq, withName(b.resolver, name), b.out, nil)
+	child.parent = b
+
+	actual, _ := b.children.LoadOrStore(name, child)
+	return actual.(*binding)
+}
+
+// withName returns a function calling the resolver with name as its first argument.
+func withName(resolver any, name string) any {
+	fn := reflect.ValueOf(resolver)
+	funcType := fn.Type()
+
+	in := make([]reflect.Type, funcType.NumIn()-1)
+	for i := range in {
+		in[i] = funcType.In(i + 1)
+	}
+	out := make([]reflect.Type, funcType.NumOut())
+	for i := range out {
+		out[i] = funcType.Out(i)
+	}
+
+	call := fn.Call
+	if funcType.IsVariadic() {
+		call = fn.CallSlice
+	}
+	nameValue := reflect.ValueOf(name).Convert(funcType.In(0))
+	return reflect.MakeFunc(reflect.FuncOf(in, out, funcType.IsVariadic()), func(arguments []reflect.Value) []reflect.Value {
+		return call(append([]reflect.Value{nameValue}, arguments...))
+	}).Interface()
+}
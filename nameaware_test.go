@@ -0,0 +1,73 @@
+package di
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type namedCache struct {
+	backend string
+	logger  Logger
+}
+
+func (c *namedCache) Get(key string) string { return c.backend }
+
+func TestWithNameAware(t *testing.T) {
+	newContainer := func(t *testing.T, options ...BindOption) (*Container, *[]string) {
+		container := New()
+		var calls []string
+		require.NoError(t, container.Bind(func() Logger { return &loggerImpl{} }))
+		err := container.Bind(func(name string, logger Logger) Cache {
+			calls = append(calls, name)
+			return &namedCache{backend: name, logger: logger}
+		}, append(options, WithNameAware())...)
+		require.NoError(t, err)
+		return container, &calls
+	}
+
+	t.Run("factory receives the resolution name", func(t *testing.T) {
+		container, calls := newContainer(t)
+
+		var redis, memcached, redisAgain, defaultCache Cache
+		require.NoError(t, container.ResolveNamed(&redis, "redis"))
+		require.NoError(t, container.ResolveNamed(&memcached, "memcached"))
+		require.NoError(t, container.ResolveNamed(&redisAgain, "redis"))
+		require.NoError(t, container.Resolve(&defaultCache))
+
+		assert.Equal(t, "redis", redis.Get(""))
+		assert.Equal(t, "memcached", memcached.Get(""))
+		assert.Same(t, redis, redisAgain)
+		assert.Equal(t, "", defaultCache.Get(""))
+		assert.NotNil(t, redis.(*namedCache).logger)
+		assert.Equal(t, []string{"redis", "memcached", ""}, *calls)
+		assert.NoError(t, container.Validate())
+	})
+
+	t.Run("transient bindings construct per resolution", func(t *testing.T) {
+		container, calls := newContainer(t, WithTransient())
+
+		var first, second Cache
+		require.NoError(t, container.ResolveNamed(&first, "redis"))
+		require.NoError(t, container.ResolveNamed(&second, "redis"))
+		assert.NotSame(t, first, second)
+		assert.Equal(t, []string{"redis", "redis"}, *calls)
+	})
+
+	t.Run("explicit named bindings take precedence", func(t *testing.T) {
+		container, calls := newContainer(t)
+		explicit := &namedCache{backend: "explicit"}
+		require.NoError(t, container.BindNamed("redis", func() Cache { return explicit }))
+
+		var redis Cache
+		require.NoError(t, container.ResolveNamed(&redis, "redis"))
+		assert.Same(t, explicit, redis)
+		assert.Empty(t, *calls)
+	})
+
+	t.Run("factory must take the name", func(t *testing.T) {
+		err := New().Bind(func() Cache { return &namedCache{} }, WithNameAware())
+		assert.EqualError(t, err, "name-aware factory must take the name as its first parameter")
+	})
+}
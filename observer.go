@@ -0,0 +1,28 @@
+package di
+
+import (
+	"reflect"
+	"time"
+)
+
+// Observer is notified around every binding resolution, including the resolution of dependencies,
+// e.g. to export metrics. Its methods are called synchronously from the resolving goroutine and
+// must be safe for concurrent use.
+type Observer interface {
+	// OnResolveStart is called before the binding registered under type t and name is resolved.
+	OnResolveStart(t reflect.Type, name string)
+	// OnResolveEnd is called once the resolution is done, with its duration and error, if any.
+	OnResolveEnd(t reflect.Type, name string, d time.Duration, err error)
+}
+
+// SetObserver sets the observer notified of resolutions; nil removes it.
+func (c *Container) SetObserver(observer Observer) {
+	var stored *Observer
+	if observer != nil {
+		stored = &observer
+	}
+
+	c.configure(func(r *registry) {
+		r.observer.Store(stored)
+	})
+}
@@ -0,0 +1,90 @@
+package di
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingObserver struct {
+	mutex  sync.Mutex
+	events []string
+}
+
+func (o *recordingObserver) OnResolveStart(t reflect.Type, name string) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.events = append(o.events, fmt.Sprintf("start %s '%s'", t, name))
+}
+
+func (o *recordingObserver) OnResolveEnd(t reflect.Type, name string, d time.Duration, err error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.events = append(o.events, fmt.Sprintf("end %s '%s' %v", t, name, err))
+}
+
+func TestContainer_SetObserver(t *testing.T) {
+	t.Run("two-level resolution", func(t *testing.T) {
+		container := New()
+		observer := &recordingObserver{}
+		container.SetObserver(observer)
+
+		err := container.Bind(func(db Database) UserService {
+			return &userServiceImpl{db: db}
+		})
+		require.NoError(t, err)
+		err = container.Bind(func() Database {
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		var userService UserService
+		require.NoError(t, container.Resolve(&userService))
+		assert.Equal(t, []string{
+			"start di.UserService ''",
+			"start di.Database ''",
+			"end di.Database '' <nil>",
+			"end di.UserService '' <nil>",
+		}, observer.events)
+	})
+
+	t.Run("failed resolution", func(t *testing.T) {
+		container := New()
+		observer := &recordingObserver{}
+		container.SetObserver(observer)
+
+		err := container.BindNamed("primary", func() (Database, error) {
+			return nil, errors.New("connection refused")
+		})
+		require.NoError(t, err)
+
+		var db Database
+		require.Error(t, container.ResolveNamed(&db, "primary"))
+		assert.Equal(t, []string{
+			"start di.Database 'primary'",
+			"end di.Database 'primary' connection refused",
+		}, observer.events)
+	})
+
+	t.Run("removing the observer", func(t *testing.T) {
+		container := New()
+		observer := &recordingObserver{}
+		container.SetObserver(observer)
+		container.SetObserver(nil)
+
+		err := container.Bind(func() Database {
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		var db Database
+		require.NoError(t, container.Resolve(&db))
+		assert.Empty(t, observer.events)
+	})
+}
@@ -0,0 +1,81 @@
+package di
+
+import (
+	"reflect"
+)
+
+// Optional is a parameter type for dependencies a constructor can do without.
+// If T is bound, the Optional holds the resolved instance; otherwise it is empty and resolution continues.
+type Optional[T any] struct {
+	value T
+	ok    bool
+}
+
+// Get returns the instance and whether T was bound.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.ok
+}
+
+// Value returns the instance, or the zero value of T if T was not bound.
+func (o Optional[T]) Value() T {
+	return o.value
+}
+
+// Ok reports whether T was bound.
+func (o Optional[T]) Ok() bool {
+	return o.ok
+}
+
+// set stores a resolved instance.
+func (o *Optional[T]) set(instance any) {
+	o.value, _ = instance.(T)
+	o.ok = true
+}
+
+// optionalType returns the Optional[T] type itself, since the marker methods are also promoted
+// to structs embedding an Optional[T].
+func (Optional[T]) optionalType() reflect.Type {
+	return reflect.TypeOf(Optional[T]{})
+}
+
+// optionalMarker is implemented by pointers to every instantiation of Optional[T] and to the structs embedding one.
+type optionalMarker interface {
+	set(instance any)
+	optionalType() reflect.Type
+}
+
+var optionalMarkerType = reflect.TypeOf((*optionalMarker)(nil)).Elem()
+
+func isOptional(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && reflect.PtrTo(t).Implements(optionalMarkerType) &&
+		reflect.New(t).Interface().(optionalMarker).optionalType() == t
+}
+
+// optionalElem returns T for an Optional[T] type.
+func optionalElem(t reflect.Type) reflect.Type {
+	get, _ := t.MethodByName("Get")
+	return get.Type.Out(0)
+}
+
+// resolveOptional resolves an Optional[T] parameter, leaving it empty if T is not bound.
+func (c *Container) resolveOptional(r *resolution, t reflect.Type) (reflect.Value, error) {
+	optional := reflect.New(t)
+
+	c.lock.RLock()
+	bound := c.satisfiable(optionalElem(t))
+	c.lock.RUnlock()
+	if !bound {
+		return optional.Elem(), nil
+	}
+
+	instance, err := c.resolveArgument(r, optionalElem(t))
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	var value any
+	if instance.IsValid() {
+		value = instance.Interface()
+	}
+	optional.Interface().(optionalMarker).set(value)
+	return optional.Elem(), nil
+}
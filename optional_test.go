@@ -0,0 +1,94 @@
+package di_test
+
+import (
+	"testing"
+
+	"github.com/ahn84/yadi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Tracer interface {
+	Trace(span string) string
+}
+
+type stdoutTracer struct{}
+
+func (stdoutTracer) Trace(span string) string { return "traced " + span }
+
+type Checkout struct {
+	tracer Tracer
+}
+
+func NewCheckout(tracer di.Optional[Tracer]) *Checkout {
+	if t, ok := tracer.Get(); ok {
+		return &Checkout{tracer: t}
+	}
+	return &Checkout{}
+}
+
+type OptionalHolder struct {
+	di.Optional[Tracer]
+	Label string
+}
+
+func TestOptional(t *testing.T) {
+	t.Run("present dependency is injected", func(t *testing.T) {
+		container := di.New()
+		require.NoError(t, container.Bind(func() Tracer { return stdoutTracer{} }))
+		require.NoError(t, container.Bind(NewCheckout))
+
+		var checkout *Checkout
+		require.NoError(t, container.Resolve(&checkout))
+		assert.Equal(t, stdoutTracer{}, checkout.tracer)
+	})
+
+	t.Run("absent dependency leaves the optional empty", func(t *testing.T) {
+		container := di.New()
+		called := false
+		err := container.Bind(func(tracer di.Optional[Tracer]) *Checkout {
+			called = true
+			assert.False(t, tracer.Ok())
+			assert.Nil(t, tracer.Value())
+			return &Checkout{}
+		})
+		require.NoError(t, err)
+
+		var checkout *Checkout
+		require.NoError(t, container.Resolve(&checkout))
+		assert.True(t, called)
+	})
+
+	t.Run("struct embedding an optional is resolved from its binding", func(t *testing.T) {
+		container := di.New()
+		require.NoError(t, container.Bind(func() OptionalHolder {
+			return OptionalHolder{Label: "bound"}
+		}))
+		require.NoError(t, container.Bind(func(holder OptionalHolder) string {
+			return holder.Label
+		}))
+
+		var label string
+		require.NoError(t, container.Resolve(&label))
+		assert.Equal(t, "bound", label)
+	})
+
+	t.Run("errors of a bound dependency are returned", func(t *testing.T) {
+		container := di.New()
+		require.NoError(t, container.Bind(func() (Tracer, error) { return nil, assert.AnError }))
+		require.NoError(t, container.Bind(NewCheckout))
+
+		var checkout *Checkout
+		assert.ErrorIs(t, container.Resolve(&checkout), assert.AnError)
+	})
+
+	t.Run("absent dependency does not panic", func(t *testing.T) {
+		container := di.New()
+		container.SetPanicOnMissing(true)
+		require.NoError(t, container.Bind(NewCheckout))
+
+		var checkout *Checkout
+		require.NoError(t, container.Resolve(&checkout))
+		assert.Nil(t, checkout.tracer)
+	})
+}
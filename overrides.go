@@ -0,0 +1,27 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// ResolveWith resolves the target while injecting the override values into every factory parameter of
+// their type, instead of resolving those parameters from bindings, e.g. to build a service on a mock
+// Database in a test without mutating the container. Each value must be assignable to its type.
+//
+// Like ResolveWithLogger, the overrides apply to this resolution only: singletons that receive an override
+// are constructed for this call without being cached, and singletons that are already cached are returned
+// as they are. Parameters mapped to named bindings with WithParamNames are not overridden.
+func (c *Container) ResolveWith(target interface{}, overrides map[reflect.Type]interface{}) error {
+	r := c.begin(context.Background())
+	r.overrides = make(map[reflect.Type]reflect.Value, len(overrides))
+	for t, value := range overrides {
+		v := valueOf(value, t)
+		if !v.Type().AssignableTo(t) {
+			return fmt.Errorf("override %s is not assignable to %s", v.Type(), t)
+		}
+		r.overrides[t] = v
+	}
+	return c.resolveNamed(r, target, "", lifetimeDefault)
+}
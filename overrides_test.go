@@ -0,0 +1,57 @@
+package di
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer_ResolveWith(t *testing.T) {
+	databaseType := reflect.TypeOf((*Database)(nil)).Elem()
+
+	newContainer := func(t *testing.T) *Container {
+		container := New()
+		require.NoError(t, container.Bind(func() Database { return &mockDatabase{} }))
+		require.NoError(t, container.Bind(func(db Database) UserService {
+			return &userServiceImpl{db: db}
+		}))
+		return container
+	}
+
+	t.Run("constructor receives the override", func(t *testing.T) {
+		container := newContainer(t)
+		mock := &mockDatabase{connected: true}
+
+		var userService UserService
+		err := container.ResolveWith(&userService, map[reflect.Type]interface{}{databaseType: mock})
+		require.NoError(t, err)
+		assert.Same(t, mock, userService.(*userServiceImpl).db)
+	})
+
+	t.Run("container is left untouched", func(t *testing.T) {
+		container := newContainer(t)
+		mock := &mockDatabase{connected: true}
+
+		var overridden UserService
+		require.NoError(t, container.ResolveWith(&overridden, map[reflect.Type]interface{}{databaseType: mock}))
+
+		var userService UserService
+		require.NoError(t, container.Resolve(&userService))
+		assert.NotSame(t, overridden, userService)
+		assert.NotSame(t, mock, userService.(*userServiceImpl).db)
+
+		var db Database
+		require.NoError(t, container.Resolve(&db))
+		assert.NotSame(t, mock, db)
+	})
+
+	t.Run("error when the override is not assignable", func(t *testing.T) {
+		container := newContainer(t)
+
+		var userService UserService
+		err := container.ResolveWith(&userService, map[reflect.Type]interface{}{databaseType: "postgres"})
+		assert.EqualError(t, err, "override string is not assignable to di.Database")
+	})
+}
@@ -0,0 +1,36 @@
+package di
+
+// WithProfile limits the binding to a profile, such as "dev" or "prod", see SetActiveProfiles.
+// Profile bindings don't replace other bindings with the same type and name: while its profile is active,
+// a profile binding takes precedence over bindings without a profile, which are always eligible.
+// A later binding without a profile or condition replaces every candidate, so register defaults first.
+func WithProfile(profile string) BindOption {
+	return func(config *bindConfig) {
+		config.profile = profile
+	}
+}
+
+// SetActiveProfiles replaces the set of active profiles. Bindings registered with WithProfile
+// for any other profile are ignored during resolution.
+func (c *Container) SetActiveProfiles(profiles ...string) {
+	active := make(map[string]bool, len(profiles))
+	for _, profile := range profiles {
+		active[profile] = true
+	}
+
+	c.configure(func(r *registry) {
+		r.profiles = active
+	})
+}
+
+// WithPriority ranks the binding among candidates with the same type and name: the eligible candidate
+// with the highest priority takes effect, e.g. a test binding with priority 100 beats a default of 0,
+// and two eligible candidates registered with the same top priority are ambiguous. Bindings have
+// priority 0 by default. Like conditional and profile bindings, a binding with a priority does not
+// replace existing candidates.
+func WithPriority(priority int) BindOption {
+	return func(config *bindConfig) {
+		config.priority = priority
+		config.ranked = true
+	}
+}
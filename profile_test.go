@@ -0,0 +1,147 @@
+package di
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type realDatabase struct{}
+
+func (realDatabase) Connect() error { return nil }
+
+func TestContainer_Profiles(t *testing.T) {
+	newContainer := func(t *testing.T) *Container {
+		container := New()
+		err := container.Bind(func() Database {
+			return &mockDatabase{}
+		}, WithProfile("dev"))
+		require.NoError(t, err)
+		err = container.Bind(func() Database {
+			return realDatabase{}
+		}, WithProfile("prod"))
+		require.NoError(t, err)
+		return container
+	}
+
+	t.Run("dev profile resolves the mock", func(t *testing.T) {
+		container := newContainer(t)
+		container.SetActiveProfiles("dev")
+
+		var db Database
+		require.NoError(t, container.Resolve(&db))
+		assert.IsType(t, &mockDatabase{}, db)
+	})
+
+	t.Run("prod profile resolves the real implementation", func(t *testing.T) {
+		container := newContainer(t)
+		container.SetActiveProfiles("prod")
+
+		var db Database
+		require.NoError(t, container.Resolve(&db))
+		assert.IsType(t, realDatabase{}, db)
+	})
+
+	t.Run("ambiguous match", func(t *testing.T) {
+		container := newContainer(t)
+		container.SetActiveProfiles("dev", "prod")
+
+		var db Database
+		err := container.Resolve(&db)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ambiguous bindings for type di.Database")
+	})
+
+	t.Run("bindings without a profile are always eligible", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func() Database {
+			return realDatabase{}
+		})
+		require.NoError(t, err)
+		err = container.Bind(func() Database {
+			return &mockDatabase{}
+		}, WithProfile("dev"))
+		require.NoError(t, err)
+
+		var db Database
+		require.NoError(t, container.Resolve(&db))
+		assert.IsType(t, realDatabase{}, db)
+
+		container.SetActiveProfiles("dev")
+		var dev Database
+		require.NoError(t, container.Resolve(&dev))
+		assert.IsType(t, &mockDatabase{}, dev)
+	})
+
+	t.Run("no profile active", func(t *testing.T) {
+		container := newContainer(t)
+
+		var db Database
+		assert.ErrorIs(t, container.Resolve(&db), ErrBindingNotFound)
+	})
+}
+
+func TestWithPriority(t *testing.T) {
+	t.Run("highest priority wins", func(t *testing.T) {
+		container := New()
+		require.NoError(t, container.Bind(func() Database { return realDatabase{} }))
+		require.NoError(t, container.Bind(func() Database { return &mockDatabase{} }, WithPriority(100)))
+		require.NoError(t, container.Bind(func() Database { return &mockDatabase{connected: true} }, WithPriority(50)))
+
+		var db Database
+		require.NoError(t, container.Resolve(&db))
+		assert.False(t, db.(*mockDatabase).connected)
+	})
+
+	t.Run("lower priority takes effect when the higher one is not eligible", func(t *testing.T) {
+		container := New()
+		enabled := false
+		require.NoError(t, container.Bind(func() Database { return realDatabase{} }))
+		err := container.Bind(func() Database {
+			return &mockDatabase{}
+		}, WithPriority(100), WithCondition(func() bool { return enabled }))
+		require.NoError(t, err)
+
+		var db Database
+		require.NoError(t, container.Resolve(&db))
+		assert.IsType(t, realDatabase{}, db)
+
+		enabled = true
+		require.NoError(t, container.ResolveTransient(&db))
+		assert.IsType(t, &mockDatabase{}, db)
+	})
+
+	t.Run("priority takes precedence over profiles", func(t *testing.T) {
+		container := New()
+		container.SetActiveProfiles("dev")
+		require.NoError(t, container.Bind(func() Database { return realDatabase{} }, WithPriority(10)))
+		require.NoError(t, container.Bind(func() Database { return &mockDatabase{} }, WithProfile("dev")))
+
+		var db Database
+		require.NoError(t, container.Resolve(&db))
+		assert.IsType(t, realDatabase{}, db)
+	})
+
+	t.Run("error when the top priority is shared", func(t *testing.T) {
+		container := New()
+		require.NoError(t, container.Bind(func() Database { return realDatabase{} }, WithPriority(100)))
+		require.NoError(t, container.Bind(func() Database { return &mockDatabase{} }, WithPriority(100)))
+		require.NoError(t, container.Bind(func() Database { return &mockDatabase{} }, WithPriority(1)))
+
+		var db Database
+		err := container.Resolve(&db)
+		assert.EqualError(t, err, "ambiguous bindings for type di.Database with name '': several bindings have priority 100")
+	})
+
+	t.Run("tie below the top priority is not an error", func(t *testing.T) {
+		container := New()
+		require.NoError(t, container.Bind(func() Database { return &mockDatabase{} }, WithPriority(1)))
+		require.NoError(t, container.Bind(func() Database { return &mockDatabase{} }, WithPriority(1)))
+		require.NoError(t, container.Bind(func() Database { return realDatabase{} }, WithPriority(2)))
+
+		var db Database
+		require.NoError(t, container.Resolve(&db))
+		assert.IsType(t, realDatabase{}, db)
+	})
+}
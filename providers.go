@@ -0,0 +1,79 @@
+package di
+
+import (
+	"context"
+	"reflect"
+)
+
+// Providers is a parameter type that injects one provider per binding of T, in registration order.
+// Calling a provider resolves its binding, so a plugin host can enumerate plugins and construct
+// only the ones it needs.
+type Providers[T any] []func() (T, error)
+
+// isProviders marks Providers[T] instantiations so they can be detected via reflection.
+func (Providers[T]) isProviders() {}
+
+// providersMarker is implemented by every instantiation of Providers[T].
+type providersMarker interface {
+	isProviders()
+}
+
+var providersMarkerType = reflect.TypeOf((*providersMarker)(nil)).Elem()
+
+func isProviders(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Implements(providersMarkerType)
+}
+
+// providersElem returns T for a Providers[T] type.
+func providersElem(t reflect.Type) reflect.Type {
+	return t.Elem().Out(0)
+}
+
+// newProviders constructs a Providers[T] value of the given type with a provider for every binding of T.
+func (c *Container) newProviders(t reflect.Type) reflect.Value {
+	c.lock.RLock()
+	bindings := c.orderedBindings(providersElem(t))
+	c.lock.RUnlock()
+
+	providers := reflect.MakeSlice(t, 0, len(bindings))
+	for _, b := range bindings {
+		provider := reflect.MakeFunc(t.Elem(), func([]reflect.Value) []reflect.Value {
+			instance, err := b.resolve(c, c.begin(context.Background()))
+			if err != nil {
+				return []reflect.Value{reflect.Zero(b.typ), reflect.ValueOf(&err).Elem()}
+			}
+			return []reflect.Value{valueOf(instance, b.typ), reflect.Zero(errorType)}
+		})
+		providers = reflect.Append(providers, provider)
+	}
+	return providers
+}
+
+// isProviderFunc reports whether t is func() T or func() (T, error), which is injected as a function
+// resolving T on every call.
+func isProviderFunc(t reflect.Type) bool {
+	if t.Kind() != reflect.Func || t.NumIn() != 0 || t.IsVariadic() {
+		return false
+	}
+	return t.NumOut() == 1 || t.NumOut() == 2 && t.Out(1) == errorType
+}
+
+// newProviderFunc constructs a function of the given provider type that resolves the default binding
+// of T from the container on every call. Without an error result, resolution failures panic.
+func (c *Container) newProviderFunc(t reflect.Type) reflect.Value {
+	elem := t.Out(0)
+	return reflect.MakeFunc(t, func([]reflect.Value) []reflect.Value {
+		instance := reflect.New(elem)
+		err := c.resolveNamed(c.begin(context.Background()), instance.Interface(), "", lifetimeDefault)
+		if t.NumOut() == 1 {
+			if err != nil {
+				panic(err)
+			}
+			return []reflect.Value{instance.Elem()}
+		}
+		if err != nil {
+			return []reflect.Value{reflect.Zero(elem), reflect.ValueOf(&err).Elem()}
+		}
+		return []reflect.Value{instance.Elem(), reflect.Zero(errorType)}
+	})
+}
@@ -0,0 +1,148 @@
+package di_test
+
+import (
+	"testing"
+
+	"github.com/ahn84/yadi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Plugin interface {
+	Name() string
+}
+
+type namedPlugin struct {
+	name string
+}
+
+func (p *namedPlugin) Name() string {
+	return p.name
+}
+
+type PluginHost struct {
+	providers di.Providers[Plugin]
+}
+
+func TestProviders(t *testing.T) {
+	t.Run("providers construct plugins on demand", func(t *testing.T) {
+		container := di.New()
+		constructed := map[string]int{}
+		for _, name := range []string{"auth", "metrics", "tracing"} {
+			err := container.BindNamed(name, func() Plugin {
+				constructed[name]++
+				return &namedPlugin{name: name}
+			})
+			require.NoError(t, err)
+		}
+		err := container.Bind(func(providers di.Providers[Plugin]) *PluginHost {
+			return &PluginHost{providers: providers}
+		})
+		require.NoError(t, err)
+
+		var host *PluginHost
+		require.NoError(t, container.Resolve(&host))
+		require.Len(t, host.providers, 3)
+		assert.Empty(t, constructed)
+
+		metrics, err := host.providers[1]()
+		require.NoError(t, err)
+		assert.Equal(t, "metrics", metrics.Name())
+		assert.Equal(t, map[string]int{"metrics": 1}, constructed)
+
+		again, err := host.providers[1]()
+		require.NoError(t, err)
+		assert.Same(t, metrics, again)
+	})
+
+	t.Run("provider returns the factory error", func(t *testing.T) {
+		container := di.New()
+		err := container.Bind(func() (Plugin, error) {
+			return nil, assert.AnError
+		})
+		require.NoError(t, err)
+		err = container.Bind(func(providers di.Providers[Plugin]) *PluginHost {
+			return &PluginHost{providers: providers}
+		})
+		require.NoError(t, err)
+
+		var host *PluginHost
+		require.NoError(t, container.Resolve(&host))
+		require.Len(t, host.providers, 1)
+
+		_, err = host.providers[0]()
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("no bindings", func(t *testing.T) {
+		container := di.New()
+		err := container.Bind(func(providers di.Providers[Plugin]) *PluginHost {
+			return &PluginHost{providers: providers}
+		})
+		require.NoError(t, err)
+
+		var host *PluginHost
+		require.NoError(t, container.Resolve(&host))
+		assert.Empty(t, host.providers)
+	})
+}
+
+type Worker interface {
+	Work() string
+}
+
+type worker struct {
+	id int
+}
+
+func (w *worker) Work() string {
+	return "working"
+}
+
+type WorkerPool struct {
+	newWorker func() Worker
+}
+
+func TestProviderFunctions(t *testing.T) {
+	t.Run("func() T resolves a new transient on every call", func(t *testing.T) {
+		container := di.New()
+		created := 0
+		err := container.BindTransient(func() Worker {
+			created++
+			return &worker{id: created}
+		})
+		require.NoError(t, err)
+		err = container.Bind(func(newWorker func() Worker) *WorkerPool {
+			return &WorkerPool{newWorker: newWorker}
+		})
+		require.NoError(t, err)
+
+		var pool *WorkerPool
+		require.NoError(t, container.Resolve(&pool))
+		assert.Equal(t, 0, created)
+
+		first, second := pool.newWorker(), pool.newWorker()
+		assert.NotSame(t, first, second)
+		assert.Equal(t, 2, created)
+	})
+
+	t.Run("func() (T, error) reports resolution errors", func(t *testing.T) {
+		container := di.New()
+		var provider func() (Worker, error)
+		err := container.Bind(func(newWorker func() (Worker, error)) *WorkerPool {
+			provider = newWorker
+			return &WorkerPool{}
+		})
+		require.NoError(t, err)
+
+		var pool *WorkerPool
+		require.NoError(t, container.Resolve(&pool))
+		_, err = provider()
+		assert.ErrorIs(t, err, di.ErrBindingNotFound)
+
+		require.NoError(t, container.Bind(func() Worker { return &worker{} }))
+		w, err := provider()
+		require.NoError(t, err)
+		assert.Equal(t, "working", w.Work())
+	})
+}
@@ -0,0 +1,117 @@
+package di
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+)
+
+// reentrancyDepth is how many constructions of a binding may be in progress at once before the goroutines
+// starting more are tracked, see nest.
+const reentrancyDepth = 16
+
+// construction is the chain of bindings being constructed when a factory received a container, Lazy or provider.
+// The chain only applies until the factory returns, so instances keeping the container can later resolve anything.
+// The scope applies for as long as the container is kept, so scoped bindings resolve in the scope of the factory.
+type construction struct {
+	resolving []*binding  // bindings being constructed, outermost first
+	scope     *Scope      // scope of the resolution, nil outside a scope
+	done      atomic.Bool // whether the factory returned
+}
+
+// within returns a container sharing c's registry whose resolutions continue r for as long as the binding
+// being constructed has not returned. A factory resolving its own type through it, directly or through
+// other factories, is then reported as a circular dependency instead of deadlocking or recursing forever.
+func (c *Container) within(r *resolution) *Container {
+	active := &construction{resolving: make([]*binding, len(r.resolving)), scope: r.scope}
+	copy(active.resolving, r.resolving)
+	r.injected = append(r.injected, active)
+	return &Container{registry: c.registry, injected: active}
+}
+
+// begin starts a resolution in the scope of the construction the container was injected into, continuing
+// the construction if it is still running.
+func (c *Container) begin(ctx context.Context) *resolution {
+	r := newResolution(ctx)
+	if c.injected != nil {
+		r.scope = c.injected.scope
+		if !c.injected.done.Load() {
+			r.resolving = c.injected.resolving
+		}
+	}
+	return r
+}
+
+// leave pops the innermost binding being constructed and ends the constructions injected while it was built.
+func (r *resolution) leave() {
+	r.resolving = r.resolving[:len(r.resolving)-1]
+	for len(r.injected) > 0 {
+		last := r.injected[len(r.injected)-1]
+		if len(last.resolving) <= len(r.resolving) {
+			break
+		}
+		last.done.Store(true)
+		r.injected = r.injected[:len(r.injected)-1]
+	}
+}
+
+// lock takes the lock serializing the constructions of the binding's cached instances. A factory resolving its
+// own type through a container it captured, instead of one it received, finds the lock held by its own goroutine,
+// which is reported as a circular dependency instead of deadlocking. Goroutine IDs are only read on contention.
+func (b *binding) lock() error {
+	if b.mutex.TryLock() {
+		return nil
+	}
+	if _, reentrant := b.builders.Load(goroutineID()); reentrant {
+		return b.circular()
+	}
+	b.mutex.Lock()
+	return nil
+}
+
+// build marks the current goroutine as constructing an instance while it holds the lock, and returns
+// the function unmarking it.
+func (b *binding) build() func() {
+	id := goroutineID()
+	b.builders.Store(id, true)
+	return func() { b.builders.Delete(id) }
+}
+
+// nest tracks a construction that does not take the lock, and returns the function ending it. Once more than
+// reentrancyDepth are in progress, the goroutines starting more are marked as constructing an instance, so that
+// a factory resolving its own type through a captured container fails instead of recursing until the stack
+// overflows. Shallow constructions, which is every construction without reentrancy, never read goroutine IDs.
+func (b *binding) nest() (func(), error) {
+	if b.nesting.Add(1) <= reentrancyDepth {
+		return func() { b.nesting.Add(-1) }, nil
+	}
+
+	id := goroutineID()
+	if _, reentrant := b.builders.LoadOrStore(id, true); reentrant {
+		b.nesting.Add(-1)
+		return nil, b.circular()
+	}
+	return func() {
+		b.builders.Delete(id)
+		b.nesting.Add(-1)
+	}, nil
+}
+
+// circular returns the error reporting that constructing the binding's instance requires the instance itself.
+func (b *binding) circular() error {
+	return fmt.Errorf("%w while constructing type %s with name '%s'", ErrCircularDependency, b.typ, b.name)
+}
+
+// goroutineID returns the ID of the current goroutine, parsed from the "goroutine <id> [" stack header.
+func goroutineID() uint64 {
+	var buf [64]byte
+	header := bytes.TrimPrefix(buf[:runtime.Stack(buf[:], false)], []byte("goroutine "))
+	if end := bytes.IndexByte(header, ' '); end >= 0 {
+		header = header[:end]
+	}
+	id, _ := strconv.ParseUint(string(header), 10, 64)
+	return id
+}
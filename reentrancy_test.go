@@ -0,0 +1,181 @@
+package di
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer_ReentrantResolution(t *testing.T) {
+	t.Run("singleton factory resolving its own type", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func(c *Container) (Database, error) {
+			var db Database
+			if err := c.Resolve(&db); err != nil {
+				return nil, err
+			}
+			return db, nil
+		})
+		require.NoError(t, err)
+
+		var db Database
+		err = container.Resolve(&db)
+		assert.ErrorIs(t, err, ErrCircularDependency)
+		assert.ErrorContains(t, err, "while constructing type di.Database with name ''")
+	})
+
+	t.Run("transient factory resolving its own type", func(t *testing.T) {
+		container := New()
+		err := container.BindTransient(func(c *Container) (Database, error) {
+			var db Database
+			if err := c.Resolve(&db); err != nil {
+				return nil, err
+			}
+			return db, nil
+		})
+		require.NoError(t, err)
+
+		var db Database
+		assert.ErrorIs(t, container.Resolve(&db), ErrCircularDependency)
+	})
+
+	t.Run("factory running under a timeout resolving its own type", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func(c *Container) (Database, error) {
+			var db Database
+			if err := c.Resolve(&db); err != nil {
+				return nil, err
+			}
+			return db, nil
+		}, WithTimeout(time.Minute))
+		require.NoError(t, err)
+
+		var db Database
+		assert.ErrorIs(t, container.Resolve(&db), ErrCircularDependency)
+	})
+
+	t.Run("singleton factory resolving its own type through a captured container", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func() (Database, error) {
+			var db Database
+			if err := container.Resolve(&db); err != nil {
+				return nil, err
+			}
+			return db, nil
+		})
+		require.NoError(t, err)
+
+		var db Database
+		assert.ErrorIs(t, container.Resolve(&db), ErrCircularDependency)
+	})
+
+	t.Run("transient factory resolving its own type through a captured container", func(t *testing.T) {
+		container := New()
+		err := container.BindTransient(func() (Database, error) {
+			var db Database
+			if err := container.Resolve(&db); err != nil {
+				return nil, err
+			}
+			return db, nil
+		})
+		require.NoError(t, err)
+
+		var db Database
+		assert.ErrorIs(t, container.Resolve(&db), ErrCircularDependency)
+	})
+
+	t.Run("captured container resolving through a timeout factory", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func() (Database, error) {
+			var db Database
+			if err := container.Resolve(&db); err != nil {
+				return nil, err
+			}
+			return db, nil
+		}, WithTimeout(time.Minute))
+		require.NoError(t, err)
+
+		var db Database
+		assert.ErrorIs(t, container.Resolve(&db), ErrCircularDependency)
+	})
+
+	t.Run("lazy dependency resolved during construction", func(t *testing.T) {
+		container := New()
+		err := container.BindTransient(func(lazy Lazy[Database]) (Database, error) {
+			return lazy.Resolve()
+		})
+		require.NoError(t, err)
+
+		var db Database
+		assert.ErrorIs(t, container.Resolve(&db), ErrCircularDependency)
+	})
+
+	t.Run("container kept by an instance resolves after construction", func(t *testing.T) {
+		container := New()
+		err := container.BindTransient(func(c *Container) *serviceLocator {
+			return &serviceLocator{container: c}
+		})
+		require.NoError(t, err)
+
+		var locator *serviceLocator
+		require.NoError(t, container.Resolve(&locator))
+
+		var another *serviceLocator
+		require.NoError(t, locator.container.Resolve(&another))
+		assert.NotSame(t, locator, another)
+	})
+
+	t.Run("concurrent resolutions are not reentrant", func(t *testing.T) {
+		container := New()
+		release := make(chan struct{})
+		err := container.BindTransient(func() Database {
+			<-release
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 2*reentrancyDepth; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var db Database
+				assert.NoError(t, container.Resolve(&db))
+			}()
+		}
+		close(release)
+		wg.Wait()
+	})
+
+	t.Run("concurrent resolutions of a singleton wait for its construction", func(t *testing.T) {
+		container := New()
+		release := make(chan struct{})
+		constructed := 0
+		err := container.Bind(func() Database {
+			<-release
+			constructed++
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var db Database
+				assert.NoError(t, container.Resolve(&db))
+			}()
+		}
+		close(release)
+		wg.Wait()
+		assert.Equal(t, 1, constructed)
+	})
+}
+
+type serviceLocator struct {
+	container *Container
+}
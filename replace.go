@@ -0,0 +1,116 @@
+package di
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// Replace swaps the factory of an existing binding for the type the target points to, e.g. to substitute
+// a fake in an integration test while keeping every other binding. The binding to replace is the one named
+// by WithName among the options, the default binding otherwise. Unlike Bind, Replace fails with
+// ErrBindingNotFound if there is no such binding, so it cannot register a new one by accident.
+//
+// The replacement keeps the configuration of the binding, such as its lifetime, tags, labels, group, condition
+// and profile, with the options applied on top of it, e.g. WithTransient. It is constructed on its next resolution.
+// The cached instance of the replaced binding is discarded, and closed if it implements io.Closer,
+// as are those of the reactive bindings built from it; other instances already built from it keep theirs.
+// The factory may also be a value assignable to the type.
+func (c *Container) Replace(target interface{}, factory interface{}, options ...BindOption) error {
+	targetType := reflect.TypeOf(target)
+	if targetType == nil || targetType.Kind() != reflect.Ptr {
+		return ErrNotAPointer
+	}
+	typ := targetType.Elem()
+
+	selected := &bindConfig{}
+	for _, option := range options {
+		option(selected)
+	}
+
+	if err := produces(factory, typ); err != nil {
+		return err
+	}
+	if value := reflect.ValueOf(factory); factory != nil && value.Kind() != reflect.Func {
+		typed := reflect.New(typ).Elem()
+		typed.Set(value)
+		factory = constant(typed)
+	}
+	factoryType := reflect.TypeOf(factory)
+	if err := c.validateResolverFunction(factoryType); err != nil {
+		return err
+	}
+
+	c.lock.RLock()
+	existing := c.bindings[typ][selected.name]
+	c.lock.RUnlock()
+	if existing == nil {
+		return fmt.Errorf("%w for type %s with name '%s': nothing to replace", ErrBindingNotFound, typ, selected.name)
+	}
+
+	config := existing.config.duplicate()
+	for _, option := range options {
+		option(config)
+	}
+	config.typed = nil
+	if config.nameAware && (factoryType.NumIn() == 0 || factoryType.In(0).Kind() != reflect.String) {
+		return errors.New("name-aware factory must take the name as its first parameter")
+	}
+	if len(config.params) > factoryType.NumIn() {
+		return fmt.Errorf("%d parameter names given for a resolver with %d parameters", len(config.params), factoryType.NumIn())
+	}
+	if config.fallback != nil {
+		if err := c.validateFallback(factoryType, reflect.TypeOf(config.fallback)); err != nil {
+			return err
+		}
+	}
+
+	out := slices.Index(resultTypes(factoryType), typ)
+	replacement := config.newBinding(typ, existing.name, existing.seq, factory, out, nil)
+	replacement.next = existing.next
+	replacement.eager = existing.eager
+	if config.nameAware {
+		replacement.template = config
+	}
+
+	c.lock.Lock()
+	c.bindings[typ][existing.name] = replacement
+	c.lock.Unlock()
+
+	c.invalidate(existing)
+	return nil
+}
+
+// duplicate copies the configuration, so options can be applied to the copy without affecting the bindings
+// configured by the original.
+func (config *bindConfig) duplicate() *bindConfig {
+	copied := *config
+	copied.labels = make(map[string]string, len(config.labels))
+	for key, value := range config.labels {
+		copied.labels[key] = value
+	}
+	copied.tags = slices.Clip(config.tags)
+	return &copied
+}
+
+// produces checks that the factory, or the value standing for one, yields an instance of the type.
+func produces(factory interface{}, typ reflect.Type) error {
+	factoryType := reflect.TypeOf(factory)
+	if factoryType == nil {
+		return fmt.Errorf("container: the resolver %w", ErrNotAFunction)
+	}
+	if factoryType.Kind() != reflect.Func {
+		if !factoryType.AssignableTo(typ) {
+			return fmt.Errorf("replacement %s is not assignable to %s", factoryType, typ)
+		}
+		return nil
+	}
+
+	for _, result := range resultTypes(factoryType) {
+		if result == typ {
+			return nil
+		}
+	}
+	return fmt.Errorf("replacement factory %s does not produce %s", factoryType, typ)
+}
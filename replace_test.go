@@ -0,0 +1,93 @@
+package di
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer_Replace(t *testing.T) {
+	t.Run("replaces the factory and discards the cached instance", func(t *testing.T) {
+		container := New()
+		original := &mockDatabase{}
+		require.NoError(t, container.Bind(func() Database { return original }))
+		require.NoError(t, container.Bind(func() UserService { return &userServiceImpl{} }))
+
+		var db Database
+		require.NoError(t, container.Resolve(&db))
+		assert.Same(t, original, db)
+
+		fake := &mockDatabase{connected: true}
+		require.NoError(t, container.Replace(new(Database), func() Database { return fake }))
+
+		require.NoError(t, container.Resolve(&db))
+		assert.Same(t, fake, db)
+		var userService UserService
+		assert.NoError(t, container.Resolve(&userService))
+	})
+
+	t.Run("replaces a named binding only", func(t *testing.T) {
+		container := New()
+		primary := &mockDatabase{}
+		require.NoError(t, container.Bind(func() Database { return primary }))
+		require.NoError(t, container.BindNamed("real", func() Database { return &mockDatabase{} }))
+
+		fake := &mockDatabase{connected: true}
+		require.NoError(t, container.Replace(new(Database), fake, WithName("real")))
+
+		var db Database
+		require.NoError(t, container.ResolveNamed(&db, "real"))
+		assert.Same(t, fake, db)
+		require.NoError(t, container.Resolve(&db))
+		assert.Same(t, primary, db)
+	})
+
+	t.Run("keeps the configuration of a transient binding", func(t *testing.T) {
+		container := New()
+		require.NoError(t, container.BindTransient(func() Database { return &mockDatabase{} }, WithTags("storage")))
+
+		require.NoError(t, container.Replace(new(Database), func() Database { return &mockDatabase{connected: true} }))
+
+		var first, second Database
+		require.NoError(t, container.Resolve(&first))
+		require.NoError(t, container.Resolve(&second))
+		assert.True(t, first.(*mockDatabase).connected)
+		assert.NotSame(t, first, second)
+
+		var tagged []Database
+		require.NoError(t, container.ResolveByTag("storage", &tagged))
+		assert.Len(t, tagged, 1)
+	})
+
+	t.Run("options are applied on top of the configuration", func(t *testing.T) {
+		container := New()
+		require.NoError(t, container.BindTransient(func() Database { return &mockDatabase{} }))
+
+		require.NoError(t, container.Replace(new(Database), func() Database { return &mockDatabase{} }, WithSingleton()))
+
+		var first, second Database
+		require.NoError(t, container.Resolve(&first))
+		require.NoError(t, container.Resolve(&second))
+		assert.Same(t, first, second)
+	})
+
+	t.Run("error when there is nothing to replace", func(t *testing.T) {
+		container := New()
+		require.NoError(t, container.Bind(func() Database { return &mockDatabase{} }))
+
+		err := container.Replace(new(Database), func() Database { return &mockDatabase{} }, WithName("real"))
+		assert.ErrorIs(t, err, ErrBindingNotFound)
+
+		var db Database
+		assert.ErrorIs(t, container.ResolveNamed(&db, "real"), ErrBindingNotFound)
+	})
+
+	t.Run("error when the factory produces another type", func(t *testing.T) {
+		container := New()
+		require.NoError(t, container.Bind(func() Database { return &mockDatabase{} }))
+
+		err := container.Replace(new(Database), func() UserService { return &userServiceImpl{} })
+		assert.EqualError(t, err, "replacement factory func() di.UserService does not produce di.Database")
+	})
+}
@@ -1,6 +1,7 @@
 package di_test
 
 import (
+	"io"
 	"testing"
 
 	"github.com/ahn84/yadi"
@@ -58,3 +59,247 @@ func TestResolveAll(t *testing.T) {
 		}
 	}
 }
+
+type orderedService struct {
+	name string
+}
+
+func (s *orderedService) Initialize() {}
+
+func TestResolveAllOrder(t *testing.T) {
+	names := []string{"first", "second", "third", "fourth", "fifth"}
+
+	for run := 0; run < 20; run++ {
+		c := di.New()
+		for _, name := range names {
+			name := name
+			err := c.BindNamed(name, func() Initializable {
+				return &orderedService{name: name}
+			})
+			require.NoError(t, err)
+		}
+
+		var services []Initializable
+		err := c.ResolveAll(&services)
+		require.NoError(t, err)
+
+		resolved := make([]string, 0, len(services))
+		for _, s := range services {
+			resolved = append(resolved, s.(*orderedService).name)
+		}
+		require.Equal(t, names, resolved)
+	}
+}
+
+type Messenger interface {
+	Send(message string) string
+}
+
+type emailMessenger struct{}
+
+func (emailMessenger) Send(message string) string { return "email: " + message }
+
+type smsMessenger struct{}
+
+func (smsMessenger) Send(message string) string { return "sms: " + message }
+
+func TestResolveAllNamed(t *testing.T) {
+	c := di.New()
+
+	err := c.BindNamed("email", func() Messenger {
+		return emailMessenger{}
+	})
+	require.NoError(t, err)
+
+	err = c.BindNamed("sms", func() Messenger {
+		return smsMessenger{}
+	})
+	require.NoError(t, err)
+
+	err = c.Bind(func() Messenger {
+		return emailMessenger{}
+	})
+	require.NoError(t, err)
+
+	messengers, err := c.ResolveAllNamed((*Messenger)(nil))
+	require.NoError(t, err)
+	require.Len(t, messengers, 3)
+	require.Equal(t, "email: hi", messengers["email"].(Messenger).Send("hi"))
+	require.Equal(t, "sms: hi", messengers["sms"].(Messenger).Send("hi"))
+	require.Contains(t, messengers, "")
+}
+
+func TestResolveAllNamedWithoutBindings(t *testing.T) {
+	c := di.New()
+
+	messengers, err := c.ResolveAllNamed((*Messenger)(nil))
+	require.NoError(t, err)
+	require.Empty(t, messengers)
+
+	_, err = c.ResolveAllNamed(Messenger(nil))
+	require.Error(t, err)
+}
+
+func TestResolveByTag(t *testing.T) {
+	c := di.New()
+	constructed := map[string]bool{}
+
+	bindService := func(name string, tags ...string) {
+		err := c.BindNamed(name, func() Initializable {
+			constructed[name] = true
+			return &orderedService{name: name}
+		}, di.WithTags(tags...))
+		require.NoError(t, err)
+	}
+	bindService("database", "critical")
+	bindService("cache", "critical", "background")
+	bindService("mailer", "background")
+	bindService("metrics")
+
+	var critical []Initializable
+	err := c.ResolveByTag("critical", &critical)
+	require.NoError(t, err)
+	require.Len(t, critical, 2)
+	require.Equal(t, "database", critical[0].(*orderedService).name)
+	require.Equal(t, "cache", critical[1].(*orderedService).name)
+	require.Equal(t, map[string]bool{"database": true, "cache": true}, constructed)
+
+	var background []Initializable
+	err = c.ResolveByTag("background", &background)
+	require.NoError(t, err)
+	require.Len(t, background, 2)
+	require.Equal(t, "cache", background[0].(*orderedService).name)
+	require.Equal(t, "mailer", background[1].(*orderedService).name)
+
+	var none []Initializable
+	err = c.ResolveByTag("missing", &none)
+	require.NoError(t, err)
+	require.Empty(t, none)
+
+	err = c.ResolveByTag("critical", none)
+	require.Error(t, err)
+}
+
+type closableService struct {
+	ServiceA
+	closed bool
+}
+
+func (s *closableService) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestResolveCapable(t *testing.T) {
+	c := di.New()
+	closable := &closableService{}
+
+	err := c.Bind(func() Initializable {
+		return &ServiceA{}
+	})
+	require.NoError(t, err)
+	err = c.BindNamed("closable", func() Initializable {
+		return closable
+	})
+	require.NoError(t, err)
+	err = c.BindNamed("serviceB", func() Initializable {
+		return &ServiceB{}
+	})
+	require.NoError(t, err)
+
+	capable, err := c.ResolveCapable(new(Initializable), new(io.Closer))
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{closable}, capable)
+
+	_, err = c.ResolveCapable(new(Initializable), new(ServiceA))
+	require.EqualError(t, err, "capability di_test.ServiceA is not an interface type")
+}
+
+func TestSpread(t *testing.T) {
+	t.Run("each element is a binding", func(t *testing.T) {
+		c := di.New()
+		err := c.Bind(func() []Initializable {
+			return []Initializable{
+				&orderedService{name: "auth"},
+				&orderedService{name: "orders"},
+				&orderedService{name: "billing"},
+			}
+		}, di.WithSpread(), di.WithName("handlers"))
+		require.NoError(t, err)
+
+		var handlers []Initializable
+		require.NoError(t, c.ResolveAll(&handlers))
+		require.Len(t, handlers, 3)
+		require.Equal(t, "auth", handlers[0].(*orderedService).name)
+		require.Equal(t, "billing", handlers[2].(*orderedService).name)
+
+		var orders Initializable
+		require.NoError(t, c.ResolveNamed(&orders, "handlers#1"))
+		require.Same(t, handlers[1], orders)
+	})
+
+	t.Run("custom namer", func(t *testing.T) {
+		c := di.New()
+		err := c.Bind(func() ([]Initializable, error) {
+			return []Initializable{&orderedService{name: "auth"}, &orderedService{name: "orders"}}, nil
+		}, di.WithSpreadNamer(func(index int, element interface{}) string {
+			return element.(*orderedService).name
+		}))
+		require.NoError(t, err)
+
+		var auth Initializable
+		require.NoError(t, c.ResolveNamed(&auth, "auth"))
+		require.Equal(t, "auth", auth.(*orderedService).name)
+	})
+
+	t.Run("factory must return a slice", func(t *testing.T) {
+		err := di.New().Bind(func() Initializable { return &ServiceA{} }, di.WithSpread())
+		require.ErrorContains(t, err, "spread factory must return a slice")
+	})
+}
+
+type BackgroundWorker struct {
+	queue string
+}
+
+func TestResolveAllConcrete(t *testing.T) {
+	t.Run("collects every named binding of a concrete type", func(t *testing.T) {
+		c := di.New()
+		for _, queue := range []string{"emails", "reports", "billing"} {
+			queue := queue
+			err := c.BindNamed(queue, func() *BackgroundWorker {
+				return &BackgroundWorker{queue: queue}
+			})
+			require.NoError(t, err)
+		}
+
+		var workers []*BackgroundWorker
+		require.NoError(t, c.ResolveAll(&workers))
+		require.Len(t, workers, 3)
+
+		queues := make([]string, 0, len(workers))
+		for _, worker := range workers {
+			queues = append(queues, worker.queue)
+		}
+		require.Equal(t, []string{"emails", "reports", "billing"}, queues)
+
+		var emails *BackgroundWorker
+		require.NoError(t, c.ResolveNamed(&emails, "emails"))
+		require.Same(t, emails, workers[0])
+	})
+
+	t.Run("interface and concrete element types match their own bindings", func(t *testing.T) {
+		c := di.New()
+		require.NoError(t, c.Bind(func() *ServiceA { return &ServiceA{} }))
+		require.NoError(t, c.Bind(func() Initializable { return &ServiceB{} }))
+
+		var concrete []*ServiceA
+		require.NoError(t, c.ResolveAll(&concrete))
+		require.Len(t, concrete, 1)
+
+		var services []Initializable
+		require.NoError(t, c.ResolveAll(&services))
+		require.Len(t, services, 1)
+		require.IsType(t, &ServiceB{}, services[0])
+	})
+}
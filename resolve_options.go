@@ -0,0 +1,84 @@
+package di
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// ResolveOption configures a single call to Resolve or ResolveNamed, without changing the binding.
+type ResolveOption func(*resolveConfig)
+
+// resolveConfig holds the per-call behavior set by resolve options.
+type resolveConfig struct {
+	lifetime lifetime      // lifetime of the resolved binding for this call
+	timeout  time.Duration // how long the resolution may take, zero for no limit
+}
+
+// WithForceTransient constructs a new instance even if the binding is a singleton, leaving the cached
+// instance untouched, like ResolveTransient. Dependencies are resolved with their own lifetimes.
+func WithForceTransient() ResolveOption {
+	return func(config *resolveConfig) {
+		config.lifetime = lifetimeTransient
+	}
+}
+
+// WithResolveTimeout bounds the resolution by a context deadline, as ResolveContext would with a context
+// created by context.WithTimeout. Once it expires, the context's error is returned and the target is left
+// untouched, even if a factory ignores the deadline: the resolution runs in a separate goroutine, which is
+// abandoned like the factories bound with WithTimeout. No further instance is constructed after the deadline;
+// factories accepting a context.Context receive it, but running ones are not interrupted.
+func WithResolveTimeout(timeout time.Duration) ResolveOption {
+	return func(config *resolveConfig) {
+		config.timeout = timeout
+	}
+}
+
+// resolveWith resolves the target from ctx with the given options applied.
+func (c *Container) resolveWith(ctx context.Context, target interface{}, name string, options []ResolveOption) error {
+	config := resolveConfig{lifetime: lifetimeDefault}
+	for _, option := range options {
+		option(&config)
+	}
+
+	if config.timeout <= 0 {
+		return c.resolveNamed(c.begin(ctx), target, name, config.lifetime)
+	}
+
+	targetValue := reflect.ValueOf(target)
+	if err := checkTarget(targetValue); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, config.timeout)
+	defer cancel()
+
+	type result struct {
+		instance any
+		err      error
+		panicked any // panic of the resolution, e.g. with SetPanicOnMissing, raised again by the caller
+	}
+	done := make(chan result, 1)
+	r := c.begin(ctx)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- result{panicked: p}
+			}
+		}()
+		instance, err := c.resolveType(r, targetValue.Elem().Type(), name, config.lifetime)
+		done <- result{instance: instance, err: err}
+	}()
+
+	select {
+	case res := <-done:
+		if res.panicked != nil {
+			panic(res.panicked)
+		}
+		if res.err != nil {
+			return res.err
+		}
+		return assign(targetValue.Elem(), res.instance)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
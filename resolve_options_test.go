@@ -0,0 +1,80 @@
+package di
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer_ResolveOptions(t *testing.T) {
+	t.Run("force transient bypasses the singleton cache", func(t *testing.T) {
+		container := New()
+
+		calls := 0
+		err := container.Bind(func() *connection {
+			calls++
+			return &connection{}
+		})
+		require.NoError(t, err)
+
+		var cached *connection
+		require.NoError(t, container.Resolve(&cached))
+
+		var fresh *connection
+		require.NoError(t, container.Resolve(&fresh, WithForceTransient()))
+		assert.NotSame(t, cached, fresh)
+		assert.Equal(t, 2, calls)
+
+		var again *connection
+		require.NoError(t, container.Resolve(&again))
+		assert.Same(t, cached, again)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("force transient applies to named bindings", func(t *testing.T) {
+		container := New()
+
+		err := container.BindNamed("primary", func() *connection { return &connection{dsn: "primary"} })
+		require.NoError(t, err)
+
+		var cached, fresh *connection
+		require.NoError(t, container.ResolveNamed(&cached, "primary"))
+		require.NoError(t, container.ResolveNamed(&fresh, "primary", WithForceTransient()))
+		assert.NotSame(t, cached, fresh)
+		assert.Equal(t, "primary", fresh.dsn)
+	})
+
+	t.Run("resolve timeout is passed to factories", func(t *testing.T) {
+		container := New()
+
+		err := container.BindTransient(func(ctx context.Context) (*connection, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+		require.NoError(t, err)
+
+		var conn *connection
+		err = container.Resolve(&conn, WithResolveTimeout(10*time.Millisecond))
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("resolve timeout bounds factories ignoring the context", func(t *testing.T) {
+		container := New()
+		release := make(chan struct{})
+		defer close(release)
+
+		err := container.BindTransient(func() *connection {
+			<-release
+			return &connection{dsn: "late"}
+		})
+		require.NoError(t, err)
+
+		var conn *connection
+		err = container.Resolve(&conn, WithResolveTimeout(10*time.Millisecond))
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Nil(t, conn)
+	})
+}
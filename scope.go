@@ -0,0 +1,105 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// WithScoped makes the binding scoped: it is constructed once per Scope and shared within it,
+// e.g. once per HTTP request. Scoped bindings can only be resolved through a Scope.
+func WithScoped() BindOption {
+	return func(config *bindConfig) {
+		config.scoped = true
+		config.singleton = false
+	}
+}
+
+// Scope caches the instances of scoped bindings for one logical operation, see BeginScope.
+// Singletons resolved through a scope are still shared by the whole container, and transient
+// bindings still construct a new instance every time. It is safe for concurrent use.
+type Scope struct {
+	container *Container
+	mutex     sync.Mutex
+	instances map[*binding]any // scoped instances by binding
+	order     []any            // scoped instances in construction order
+	closed    bool
+}
+
+// BeginScope starts a scope. Close it once the operation is done to dispose its scoped instances.
+func (c *Container) BeginScope() *Scope {
+	return &Scope{container: c, instances: make(map[*binding]any)}
+}
+
+// Resolve resolves the target like Container.Resolve, caching scoped bindings in the scope.
+func (s *Scope) Resolve(target interface{}) error {
+	return s.ResolveNamed(target, "")
+}
+
+// ResolveNamed resolves the named target like Container.ResolveNamed, caching scoped bindings in the scope.
+func (s *Scope) ResolveNamed(target interface{}, name string) error {
+	r := s.container.begin(context.Background())
+	r.scope = s
+	return s.container.resolveNamed(r, target, name, lifetimeDefault)
+}
+
+// Close closes the scoped instances implementing io.Closer in reverse construction order and joins
+// their errors. Resolving scoped bindings through a closed scope fails.
+func (s *Scope) Close() error {
+	s.mutex.Lock()
+	instances := s.order
+	s.closed, s.instances, s.order = true, nil, nil
+	s.mutex.Unlock()
+
+	var errs []error
+	for i := len(instances) - 1; i >= 0; i-- {
+		if closer, ok := instances[i].(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("closing %T: %w", instances[i], err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// obtain returns the scope's instance of the binding, constructing it on first use. The lock is not held
+// during construction, so scoped bindings may depend on each other.
+func (s *Scope) obtain(c *Container, r *resolution, b *binding) (any, error) {
+	if s == nil {
+		return nil, fmt.Errorf("scoped binding for type %s with name '%s' resolved outside a scope", b.typ, b.name)
+	}
+
+	s.mutex.Lock()
+	instance, exists := s.instances[b]
+	closed := s.closed
+	s.mutex.Unlock()
+	if closed {
+		return nil, fmt.Errorf("resolving type %s with name '%s': scope is closed", b.typ, b.name)
+	}
+	if exists {
+		return instance, nil
+	}
+
+	val, err := b.construct(c, r)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// Another goroutine of the scope may have constructed an instance meanwhile, or closed the scope
+	if existing, exists := s.instances[b]; exists {
+		c.dispose(val)
+		return existing, nil
+	}
+	if s.closed {
+		c.dispose(val)
+		return nil, fmt.Errorf("resolving type %s with name '%s': scope is closed", b.typ, b.name)
+	}
+	s.instances[b] = val
+	s.order = append(s.order, val)
+	return val, nil
+}
@@ -0,0 +1,97 @@
+package di
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type requestContext struct {
+	closableConnection
+}
+
+func TestContainer_BeginScope(t *testing.T) {
+	newContainer := func(t *testing.T) *Container {
+		container := New()
+		require.NoError(t, container.Bind(func() Database { return &mockDatabase{} }))
+		require.NoError(t, container.Bind(func() *requestContext { return &requestContext{} }, WithScoped()))
+		require.NoError(t, container.BindTransient(func(request *requestContext, db Database) UserService {
+			return &userServiceImpl{db: db}
+		}))
+		return container
+	}
+
+	t.Run("shared within a scope, distinct across scopes", func(t *testing.T) {
+		container := newContainer(t)
+		first, second := container.BeginScope(), container.BeginScope()
+
+		var a, b, other *requestContext
+		require.NoError(t, first.Resolve(&a))
+		require.NoError(t, first.Resolve(&b))
+		require.NoError(t, second.Resolve(&other))
+		assert.Same(t, a, b)
+		assert.NotSame(t, a, other)
+	})
+
+	t.Run("singletons stay global and transients stay transient", func(t *testing.T) {
+		container := newContainer(t)
+		scope := container.BeginScope()
+
+		var db, global Database
+		require.NoError(t, scope.Resolve(&db))
+		require.NoError(t, container.Resolve(&global))
+		assert.Same(t, global, db)
+
+		var a, b UserService
+		require.NoError(t, scope.Resolve(&a))
+		require.NoError(t, scope.Resolve(&b))
+		assert.NotSame(t, a, b)
+	})
+
+	t.Run("closing the scope disposes its instances", func(t *testing.T) {
+		container := newContainer(t)
+		scope := container.BeginScope()
+
+		var request *requestContext
+		require.NoError(t, scope.Resolve(&request))
+		require.NoError(t, scope.Close())
+		assert.True(t, request.closed)
+
+		assert.ErrorContains(t, scope.Resolve(&request), "scope is closed")
+	})
+
+	t.Run("scoped bindings need a scope", func(t *testing.T) {
+		container := newContainer(t)
+
+		var request *requestContext
+		assert.ErrorContains(t, container.Resolve(&request), "resolved outside a scope")
+	})
+
+	t.Run("lazy dependencies and providers resolve in the scope", func(t *testing.T) {
+		container := newContainer(t)
+		type handler struct {
+			request Lazy[*requestContext]
+			provide func() (*requestContext, error)
+		}
+		err := container.BindTransient(func(request Lazy[*requestContext], provide func() (*requestContext, error)) *handler {
+			return &handler{request: request, provide: provide}
+		})
+		require.NoError(t, err)
+
+		scope := container.BeginScope()
+		var h *handler
+		require.NoError(t, scope.Resolve(&h))
+
+		var request *requestContext
+		require.NoError(t, scope.Resolve(&request))
+
+		lazy, err := h.request.Resolve()
+		require.NoError(t, err)
+		assert.Same(t, request, lazy)
+
+		provided, err := h.provide()
+		require.NoError(t, err)
+		assert.Same(t, request, provided)
+	})
+}
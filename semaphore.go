@@ -0,0 +1,33 @@
+package di
+
+// WithConstructionSemaphore limits the binding to n concurrent constructions, e.g. to bound expensive
+// initializations of a transient binding. Further resolutions block until a construction finishes
+// or their context is done. A limit of zero or less means no limit, which is the default.
+func WithConstructionSemaphore(n int) BindOption {
+	return func(config *bindConfig) {
+		config.slots = n
+	}
+}
+
+func newSlots(n int) chan struct{} {
+	if n <= 0 {
+		return nil
+	}
+	return make(chan struct{}, n)
+}
+
+// limit runs the construction once a slot is free.
+func (b *binding) limit(r *resolution, construct func() (any, error)) (any, error) {
+	if b.slots == nil {
+		return construct()
+	}
+
+	select {
+	case b.slots <- struct{}{}:
+	case <-r.ctx.Done():
+		return nil, r.ctx.Err()
+	}
+	defer func() { <-b.slots }()
+
+	return construct()
+}
@@ -0,0 +1,74 @@
+package di
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer_WithConstructionSemaphore(t *testing.T) {
+	t.Run("concurrent constructions never exceed the limit", func(t *testing.T) {
+		container := New()
+		var running, peak atomic.Int32
+		err := container.BindTransient(func() Database {
+			current := running.Add(1)
+			for {
+				observed := peak.Load()
+				if current <= observed || peak.CompareAndSwap(observed, current) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			running.Add(-1)
+			return &mockDatabase{}
+		}, WithConstructionSemaphore(3))
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 30; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var db Database
+				assert.NoError(t, container.Resolve(&db))
+			}()
+		}
+		wg.Wait()
+
+		assert.LessOrEqual(t, peak.Load(), int32(3))
+		assert.Positive(t, peak.Load())
+	})
+
+	t.Run("waiting resolutions honor their context", func(t *testing.T) {
+		container := New()
+		release := make(chan struct{})
+		started := make(chan struct{})
+		err := container.BindTransient(func() Database {
+			close(started)
+			<-release
+			return &mockDatabase{}
+		}, WithConstructionSemaphore(1))
+		require.NoError(t, err)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			var db Database
+			assert.NoError(t, container.Resolve(&db))
+		}()
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		var db Database
+		assert.ErrorIs(t, container.ResolveContext(ctx, &db), context.DeadlineExceeded)
+
+		close(release)
+		<-done
+	})
+}
@@ -0,0 +1,63 @@
+package di
+
+import "reflect"
+
+// Snapshot is the registration state of a container captured by Container.Snapshot.
+type Snapshot struct {
+	bindings   map[reflect.Type]map[string]*binding
+	decorators map[reflect.Type][]*decorator
+	callbacks  map[string][]reflect.Value
+	multitons  map[multitonKey]any
+}
+
+// Snapshot captures the container's bindings, decorators, callbacks and keyed factories, so that
+// Restore can later undo every registration and removal made in between, e.g. with
+// defer c.Restore(c.Snapshot()) at the top of a test. Bindings are captured by reference, so singletons
+// cached by a binding that exists in both states are kept.
+func (c *Container) Snapshot() *Snapshot {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	s := &Snapshot{
+		bindings:   copyBindings(c.bindings),
+		decorators: make(map[reflect.Type][]*decorator, len(c.decorators)),
+		callbacks:  make(map[string][]reflect.Value, len(c.callbacks)),
+	}
+	for t, decorators := range c.decorators {
+		s.decorators[t] = decorators
+	}
+	for name, callbacks := range c.callbacks {
+		s.callbacks[name] = callbacks
+	}
+	if c.multitons != nil {
+		s.multitons = make(map[multitonKey]any, len(c.multitons))
+		for key, factory := range c.multitons {
+			s.multitons[key] = factory
+		}
+	}
+	return s
+}
+
+// Restore resets the container's bindings, decorators, callbacks and keyed factories to the state
+// captured by the snapshot. The snapshot is copied, so it can be restored more than once.
+func (c *Container) Restore(s *Snapshot) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.bindings = copyBindings(s.bindings)
+	c.decorators = make(map[reflect.Type][]*decorator, len(s.decorators))
+	for t, decorators := range s.decorators {
+		c.decorators[t] = decorators
+	}
+	c.callbacks = make(map[string][]reflect.Value, len(s.callbacks))
+	for name, callbacks := range s.callbacks {
+		c.callbacks[name] = callbacks
+	}
+	c.multitons = nil
+	if s.multitons != nil {
+		c.multitons = make(map[multitonKey]any, len(s.multitons))
+		for key, factory := range s.multitons {
+			c.multitons[key] = factory
+		}
+	}
+}
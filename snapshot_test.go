@@ -0,0 +1,67 @@
+package di
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer_Snapshot(t *testing.T) {
+	t.Run("restore undoes additions and removals", func(t *testing.T) {
+		container := New()
+		original := &mockDatabase{}
+		require.NoError(t, container.Bind(func() Database { return original }))
+		require.NoError(t, container.BindNamed("replica", func() Database { return &mockDatabase{} }))
+
+		snapshot := container.Snapshot()
+
+		require.NoError(t, container.Bind(func(db Database) UserService {
+			return &userServiceImpl{db: db}
+		}))
+		require.NoError(t, container.BindNamed("cache", func() Database { return &mockDatabase{} }))
+		require.NoError(t, container.UnbindNamed(new(Database), "replica"))
+		require.NoError(t, container.Decorate(new(Database), func(db Database) Database {
+			return &mockDatabase{connected: true}
+		}))
+
+		container.Restore(snapshot)
+
+		var userService UserService
+		assert.ErrorIs(t, container.Resolve(&userService), ErrBindingNotFound)
+		var db Database
+		assert.ErrorIs(t, container.ResolveNamed(&db, "cache"), ErrBindingNotFound)
+		assert.NoError(t, container.ResolveNamed(&db, "replica"))
+		require.NoError(t, container.Resolve(&db))
+		assert.Same(t, original, db)
+	})
+
+	t.Run("snapshot can be restored more than once", func(t *testing.T) {
+		container := New()
+		snapshot := container.Snapshot()
+
+		for i := 0; i < 2; i++ {
+			require.NoError(t, container.Bind(func() Database { return &mockDatabase{} }))
+			container.Restore(snapshot)
+
+			var db Database
+			assert.ErrorIs(t, container.Resolve(&db), ErrBindingNotFound)
+		}
+	})
+
+	t.Run("deferred restore of the global container", func(t *testing.T) {
+		Clear()
+		t.Cleanup(Clear)
+		require.NoError(t, Bind(func() Database { return &mockDatabase{} }))
+
+		func() {
+			defer Restore(TakeSnapshot())
+			require.NoError(t, Bind(func() UserService { return &userServiceImpl{} }))
+		}()
+
+		var db Database
+		assert.NoError(t, Global().Resolve(&db))
+		var userService UserService
+		assert.ErrorIs(t, Global().Resolve(&userService), ErrBindingNotFound)
+	})
+}
@@ -0,0 +1,62 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// WithSpread registers each element returned by a factory of []T, optionally with an error, as a separate
+// binding of T, so elements built in bulk can be resolved with ResolveAll or injected as []T. Elements are
+// named "<name>#<index>" after the binding name, empty by default. The factory runs once during Bind,
+// so its dependencies must be registered first.
+func WithSpread() BindOption {
+	return func(config *bindConfig) {
+		config.spread = true
+	}
+}
+
+// WithSpreadNamer is like WithSpread, naming each element with the given function instead.
+func WithSpreadNamer(namer func(index int, element interface{}) string) BindOption {
+	return func(config *bindConfig) {
+		config.spread = true
+		config.spreadNamer = namer
+	}
+}
+
+// spread calls the factory and binds every element of the returned slice as a constant.
+func (c *Container) spread(resolver interface{}, config *bindConfig) error {
+	types := resultTypes(reflect.TypeOf(resolver))
+	if len(types) != 1 || types[0].Kind() != reflect.Slice {
+		return fmt.Errorf("spread factory must return a slice, got %v", types)
+	}
+
+	values, err := c.callResolver(c.begin(context.Background()), resolver, nil)
+	if err != nil {
+		return err
+	}
+
+	namer := config.spreadNamer
+	if namer == nil {
+		namer = func(index int, _ interface{}) string {
+			return fmt.Sprintf("%s#%d", config.name, index)
+		}
+	}
+
+	elements := values[0]
+	for i := 0; i < elements.Len(); i++ {
+		element := elements.Index(i)
+		elementConfig := &bindConfig{
+			name:      namer(i, element.Interface()),
+			singleton: true,
+			lazy:      true,
+			labels:    config.labels,
+			group:     config.group,
+			tags:      config.tags,
+		}
+		if err := c.bind(constant(element), elementConfig); err != nil {
+			return err
+		}
+	}
+	return nil
+}
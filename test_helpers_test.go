@@ -9,10 +9,6 @@ type UserService interface {
 	GetUser(id int) string
 }
 
-type Logger interface {
-	Log(message string)
-}
-
 type mockDatabase struct {
 	connected bool
 }
@@ -0,0 +1,51 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// WithTimeout limits how long the factory may run. The factory is invoked in a separate goroutine and the
+// resolution fails with ErrTimeout if it does not return within the duration; its dependencies are resolved
+// beforehand and are not subject to the limit. The goroutine is abandoned, not stopped, so a factory that
+// never returns leaks it.
+func WithTimeout(timeout time.Duration) BindOption {
+	return func(config *bindConfig) {
+		config.timeout = timeout
+	}
+}
+
+// call invokes the resolver with the given arguments, giving up once the binding's timeout elapses.
+func (b *binding) call(arguments []reflect.Value) ([]reflect.Value, error) {
+	if b.timeout <= 0 {
+		return b.invoke(arguments)
+	}
+
+	type result struct {
+		values []reflect.Value
+		err    error
+	}
+	done := make(chan result, 1)
+	caller := goroutineID()
+	go func() {
+		// The factory constructs on behalf of the caller, so it must not resolve its own type either
+		if _, building := b.builders.Load(caller); building {
+			id := goroutineID()
+			b.builders.Store(id, true)
+			defer b.builders.Delete(id)
+		}
+		values, err := b.invoke(arguments)
+		done <- result{values: values, err: err}
+	}()
+
+	timer := time.NewTimer(b.timeout)
+	defer timer.Stop()
+
+	select {
+	case res := <-done:
+		return res.values, res.err
+	case <-timer.C:
+		return nil, fmt.Errorf("%w: %s did not complete within %s", ErrTimeout, b.typ, b.timeout)
+	}
+}
@@ -0,0 +1,53 @@
+package di
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithTimeout(t *testing.T) {
+	t.Run("factory exceeding the timeout fails", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func() Database {
+			time.Sleep(200 * time.Millisecond)
+			return &mockDatabase{}
+		}, WithTimeout(10*time.Millisecond))
+		require.NoError(t, err)
+
+		var db Database
+		err = container.Resolve(&db)
+		require.ErrorIs(t, err, ErrTimeout)
+		assert.Contains(t, err.Error(), "di.Database")
+		assert.Nil(t, db)
+	})
+
+	t.Run("factory within the timeout succeeds", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func(logger Logger) UserService {
+			return &userServiceImpl{}
+		}, WithTimeout(time.Second))
+		require.NoError(t, err)
+		err = container.Bind(func() Logger {
+			return &loggerImpl{}
+		})
+		require.NoError(t, err)
+
+		var userService UserService
+		require.NoError(t, container.Resolve(&userService))
+		assert.NotNil(t, userService)
+	})
+
+	t.Run("factory errors are returned", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func() (Database, error) {
+			return nil, assert.AnError
+		}, WithTimeout(time.Second))
+		require.NoError(t, err)
+
+		var db Database
+		assert.ErrorIs(t, container.Resolve(&db), assert.AnError)
+	})
+}
@@ -0,0 +1,111 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// TimingEntry records the construction of a single instance during a resolution.
+type TimingEntry struct {
+	ID       int           // sequential identifier of the entry
+	ParentID int           // ID of the construction that required this one, or -1 for a top-level construction
+	Type     reflect.Type  // type that was constructed
+	Name     string        // binding name
+	Start    time.Time     // when construction started
+	Duration time.Duration // total construction time, including dependencies
+}
+
+// RecordTimings enables or disables recording of construction timings.
+// Enabling recording discards any previously recorded entries.
+func (c *Container) RecordTimings(enabled bool) {
+	c.configure(func(r *registry) {
+		r.timingsMutex.Lock()
+		defer r.timingsMutex.Unlock()
+
+		r.recordTimings = enabled
+		if enabled {
+			r.timings = nil
+		}
+	})
+}
+
+// ExportTimings returns a copy of the construction timings recorded since RecordTimings was enabled.
+func (c *Container) ExportTimings() []TimingEntry {
+	c.timingsMutex.Lock()
+	defer c.timingsMutex.Unlock()
+
+	return append([]TimingEntry(nil), c.timings...)
+}
+
+// recordingTimings reports whether construction timings are being recorded.
+func (c *Container) recordingTimings() bool {
+	c.timingsMutex.Lock()
+	defer c.timingsMutex.Unlock()
+	return c.recordTimings
+}
+
+// startTiming records the start of a construction and returns a function that completes the entry.
+// It returns nil if timings are not being recorded.
+func (c *Container) startTiming(r *resolution, b *binding) func() {
+	c.timingsMutex.Lock()
+	defer c.timingsMutex.Unlock()
+
+	if !c.recordTimings {
+		return nil
+	}
+
+	entry := TimingEntry{
+		ID:       len(c.timings),
+		ParentID: r.timingParent,
+		Type:     b.typ,
+		Name:     b.name,
+		Start:    time.Now(),
+	}
+	c.timings = append(c.timings, entry)
+
+	parent := r.timingParent
+	r.timingParent = entry.ID
+	return func() {
+		r.timingParent = parent
+
+		c.timingsMutex.Lock()
+		defer c.timingsMutex.Unlock()
+		// The entry is gone if recording was restarted while constructing.
+		if entry.ID < len(c.timings) && c.timings[entry.ID].Start == entry.Start {
+			c.timings[entry.ID].Duration = time.Since(entry.Start)
+		}
+	}
+}
+
+// FoldedStacks formats timing entries in the folded-stack format consumed by flamegraph tools.
+// Each line holds the semicolon-separated construction path followed by the self time in microseconds.
+func FoldedStacks(entries []TimingEntry) string {
+	byID := make(map[int]TimingEntry, len(entries))
+	self := make(map[int]time.Duration, len(entries))
+	for _, entry := range entries {
+		byID[entry.ID] = entry
+		self[entry.ID] += entry.Duration
+		if entry.ParentID >= 0 {
+			self[entry.ParentID] -= entry.Duration
+		}
+	}
+
+	var builder strings.Builder
+	for _, entry := range entries {
+		var frames []string
+		for current, ok := entry, true; ok; current, ok = byID[current.ParentID] {
+			frames = append([]string{frameName(current)}, frames...)
+		}
+		fmt.Fprintf(&builder, "%s %d\n", strings.Join(frames, ";"), self[entry.ID].Microseconds())
+	}
+	return builder.String()
+}
+
+func frameName(entry TimingEntry) string {
+	if entry.Name == "" {
+		return entry.Type.String()
+	}
+	return entry.Type.String() + "(" + entry.Name + ")"
+}
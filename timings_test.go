@@ -0,0 +1,97 @@
+package di
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer_ExportTimings(t *testing.T) {
+	newGraph := func(t *testing.T) *Container {
+		container := New()
+
+		err := container.Bind(func() Database {
+			time.Sleep(time.Millisecond)
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		err = container.Bind(func() Logger {
+			return &loggerImpl{}
+		})
+		require.NoError(t, err)
+
+		err = container.Bind(func(db Database) UserService {
+			return &userServiceImpl{db: db}
+		})
+		require.NoError(t, err)
+
+		err = container.Bind(func(userService UserService, db Database, logger Logger) OrderService {
+			return &orderServiceImpl{userService: userService, db: db, logger: logger}
+		})
+		require.NoError(t, err)
+
+		return container
+	}
+
+	t.Run("nothing is recorded by default", func(t *testing.T) {
+		container := newGraph(t)
+
+		var orderService OrderService
+		require.NoError(t, container.Resolve(&orderService))
+		assert.Empty(t, container.ExportTimings())
+	})
+
+	t.Run("entries link to their parents", func(t *testing.T) {
+		container := newGraph(t)
+		container.RecordTimings(true)
+
+		var orderService OrderService
+		require.NoError(t, container.Resolve(&orderService))
+
+		entries := container.ExportTimings()
+		require.Len(t, entries, 4)
+
+		byType := make(map[string]TimingEntry)
+		for _, entry := range entries {
+			byType[entry.Type.String()] = entry
+			assert.Greater(t, entry.Duration, time.Duration(0))
+		}
+
+		order := byType["di.OrderService"]
+		assert.Equal(t, -1, order.ParentID)
+		assert.Equal(t, order.ID, byType["di.UserService"].ParentID)
+		assert.Equal(t, byType["di.UserService"].ID, byType["di.Database"].ParentID)
+		assert.Equal(t, order.ID, byType["di.Logger"].ParentID)
+		assert.GreaterOrEqual(t, order.Duration, byType["di.UserService"].Duration)
+	})
+
+	t.Run("folded stacks include the construction path", func(t *testing.T) {
+		container := newGraph(t)
+		container.RecordTimings(true)
+
+		var orderService OrderService
+		require.NoError(t, container.Resolve(&orderService))
+
+		folded := FoldedStacks(container.ExportTimings())
+		lines := strings.Split(strings.TrimSpace(folded), "\n")
+		require.Len(t, lines, 4)
+		assert.Contains(t, folded, "di.OrderService;di.UserService;di.Database ")
+		assert.Contains(t, folded, "di.OrderService;di.Logger ")
+	})
+
+	t.Run("restarting recording discards entries", func(t *testing.T) {
+		container := newGraph(t)
+		container.RecordTimings(true)
+
+		var db Database
+		require.NoError(t, container.Resolve(&db))
+		require.Len(t, container.ExportTimings(), 1)
+
+		container.RecordTimings(true)
+		assert.Empty(t, container.ExportTimings())
+	})
+}
@@ -0,0 +1,323 @@
+package di
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+)
+
+// Transaction calls fn with a staging copy of the container. Everything applied to tx is merged into the container
+// in one step if fn returns nil: binds and unbinds, decorators, callbacks, keyed factories, factories registered
+// for ImportBindings, settings such as SetLogger or OnMissing, and the singletons tx constructed along with their
+// stop hooks and services, so Close and Stop reach them. Singletons reset or replaced through tx are discarded
+// and closed on commit.
+//
+// If fn returns an error, the container is left untouched: singletons tx constructed are discarded after running
+// their stop hooks, whose errors are joined to fn's. Changes made to the container while fn runs are kept unless
+// tx changes the same binding, keyed factory or setting.
+func (c *Container) Transaction(fn func(tx *Container) error) error {
+	tx, base := c.stage()
+	if err := fn(tx); err != nil {
+		return errors.Join(err, tx.Close())
+	}
+
+	tx.lock.RLock()
+	defer tx.lock.RUnlock()
+
+	// Invalidated bindings dispose their instances, which logs through the container, so wait for the lock
+	// to be released
+	defer func() {
+		for _, b := range tx.invalidated {
+			c.invalidate(b)
+		}
+		for _, m := range tx.cleared {
+			for _, instance := range m.clear() {
+				c.dispose(instance)
+			}
+		}
+	}()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// Copies that were written only to construct an instance take over an instance the binding cached meanwhile
+	for inherited, owned := range tx.owned {
+		if !slices.Contains(tx.invalidated, inherited) && owned.concrete.Load() == nil {
+			if cached := inherited.state().concrete.Load(); cached != nil {
+				owned.concrete.Store(cached)
+			}
+		}
+	}
+
+	for t, bindings := range tx.bindings {
+		for name, b := range bindings {
+			if base.bindings[t][name] == b {
+				continue
+			}
+			if _, exists := c.bindings[t]; !exists {
+				c.bindings[t] = make(map[string]*binding)
+			}
+			c.bindings[t][name] = b
+		}
+	}
+
+	for t, bindings := range base.bindings {
+		for name, b := range bindings {
+			if _, exists := tx.bindings[t][name]; !exists && c.bindings[t][name] == b {
+				delete(c.bindings[t], name)
+			}
+		}
+	}
+
+	mergeLists(c.decorators, base.decorators, tx.decorators, sortDecorators)
+	mergeLists(c.callbacks, base.callbacks, tx.callbacks, nil)
+
+	for key, m := range tx.multitons {
+		if base.multitons[key] != m {
+			if c.multitons == nil {
+				c.multitons = make(map[multitonKey]any)
+			}
+			c.multitons[key] = m
+		}
+	}
+	for key, m := range base.multitons {
+		if _, exists := tx.multitons[key]; !exists && c.multitons[key] == m {
+			delete(c.multitons, key)
+		}
+	}
+
+	for _, setting := range tx.settings {
+		setting(c.registry)
+		if c.staging {
+			c.settings = append(c.settings, setting)
+		}
+	}
+
+	// Services started in tx run before the services the container has not started yet
+	c.stoppers = append(c.stoppers, tx.stoppers...)
+	started := append(slices.Clip(c.services[:c.running]), tx.services[:tx.running]...)
+	c.services = append(append(started, c.services[c.running:]...), tx.services[tx.running:]...)
+	c.running += tx.running
+
+	c.timingsMutex.Lock()
+	if c.recordTimings {
+		offset := len(c.timings)
+		for _, entry := range tx.timings {
+			entry.ID += offset
+			if entry.ParentID >= 0 {
+				entry.ParentID += offset
+			}
+			c.timings = append(c.timings, entry)
+		}
+	}
+	c.timingsMutex.Unlock()
+
+	if tx.seq > c.seq {
+		c.seq = tx.seq
+	}
+	return nil
+}
+
+// configure applies a setting to the container. Settings applied to the staging container of a Transaction are
+// recorded, so they are applied to the container as well when the transaction commits.
+func (c *Container) configure(setting func(r *registry)) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	setting(c.registry)
+	if c.staging {
+		c.settings = append(c.settings, setting)
+	}
+}
+
+// stage returns a copy of the container sharing its bindings, along with a copy of the state it started from.
+func (c *Container) stage() (*Container, *registry) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	tx := &Container{registry: &registry{
+		bindings:       copyBindings(c.bindings),
+		decorators:     copyLists(c.decorators),
+		callbacks:      copyLists(c.callbacks),
+		factories:      make(map[string]map[string]interface{}, len(c.factories)),
+		multitons:      copyMultitons(c.multitons),
+		logger:         c.logger,
+		seq:            c.seq,
+		deferEager:     c.deferEager,
+		panicOnMissing: c.panicOnMissing,
+		onMissing:      c.onMissing,
+		profiles:       c.profiles,
+		staging:        true,
+		inherited:      make(map[any]bool),
+		owned:          make(map[*binding]*binding),
+	}}
+	tx.implicitInterfaces = c.implicitInterfaces
+	tx.recordTimings = c.recordingTimings()
+	tx.observer.Store(c.observer.Load())
+	for _, named := range c.bindings {
+		for _, b := range named {
+			for ; b != nil; b = b.next {
+				tx.inherited[b] = true
+			}
+		}
+	}
+	for _, m := range c.multitons {
+		tx.inherited[m] = true
+	}
+	for typeName, factories := range c.factories {
+		tx.factories[typeName] = make(map[string]interface{}, len(factories))
+		for name, factory := range factories {
+			tx.factories[typeName][name] = factory
+		}
+	}
+
+	base := &registry{
+		bindings:   copyBindings(c.bindings),
+		decorators: copyLists(c.decorators),
+		callbacks:  copyLists(c.callbacks),
+		multitons:  copyMultitons(c.multitons),
+	}
+	return tx, base
+}
+
+// isInherited reports whether the binding is shared with the container the transaction stages, including
+// the bindings created per name from a shared name-aware binding. The set of shared bindings is fixed by stage.
+func (c *Container) isInherited(b *binding) bool {
+	if !c.staging {
+		return false
+	}
+	if b.parent != nil {
+		b = b.parent
+	}
+	return c.inherited[b]
+}
+
+// own returns the binding standing for b in a staging container, copying b on first write if it is shared with
+// the container the transaction stages, so that an aborted transaction leaves it untouched. Copies start without
+// cached instances. It returns nil if b was shared but the transaction no longer registers it.
+func (c *Container) own(b *binding) *binding {
+	if b.parent != nil {
+		switch owner := c.own(b.parent); owner {
+		case nil:
+			return nil
+		case b.parent:
+			return b
+		default:
+			return owner.forName(b.name)
+		}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if owned, exists := c.owned[b]; exists {
+		return owned
+	}
+	if !c.inherited[b] {
+		return b
+	}
+
+	// Copy the inherited candidates registered for the type and name, relinking the chain
+	var chain []*binding
+	for candidate := c.bindings[b.typ][b.name]; candidate != nil; candidate = candidate.next {
+		chain = append(chain, candidate)
+	}
+	if !slices.Contains(chain, b) {
+		return nil
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		if c.inherited[chain[i]] && c.owned[chain[i]] == nil {
+			owned := chain[i].duplicate()
+			c.owned[chain[i]] = owned
+			chain[i] = owned
+		}
+		chain[i].next = nil
+		if i+1 < len(chain) {
+			chain[i].next = chain[i+1]
+		}
+	}
+	c.bindings[b.typ][b.name] = chain[0]
+	return c.owned[b]
+}
+
+// duplicate returns a binding with the same registration as b, without its cached instances.
+func (b *binding) duplicate() *binding {
+	copied := b.config.newBinding(b.typ, b.name, b.seq, b.resolver, b.out, nil)
+	copied.template = b.template
+	copied.eager = b.eager
+	b.dependents.Range(func(key, value any) bool {
+		copied.dependents.Store(key, value)
+		return true
+	})
+	return copied
+}
+
+func copyBindings(bindings map[reflect.Type]map[string]*binding) map[reflect.Type]map[string]*binding {
+	copied := make(map[reflect.Type]map[string]*binding, len(bindings))
+	for t, named := range bindings {
+		copied[t] = make(map[string]*binding, len(named))
+		for name, b := range named {
+			copied[t][name] = b
+		}
+	}
+	return copied
+}
+
+// copyLists copies a map of lists such as decorators or callbacks. The lists themselves are shared,
+// since the container copies them before changing them.
+func copyLists[K comparable, E any](lists map[K][]E) map[K][]E {
+	copied := make(map[K][]E, len(lists))
+	for key, list := range lists {
+		copied[key] = list
+	}
+	return copied
+}
+
+func copyMultitons(multitons map[multitonKey]any) map[multitonKey]any {
+	if multitons == nil {
+		return nil
+	}
+	copied := make(map[multitonKey]any, len(multitons))
+	for key, m := range multitons {
+		copied[key] = m
+	}
+	return copied
+}
+
+// mergeLists merges into current the changes a transaction made to the lists it started from as base: entries
+// the transaction added are appended, and entries it dropped, e.g. with Clear, are removed. Entries added to current
+// meanwhile are kept. Rewritten lists are passed to order, if not nil, to restore their ordering.
+func mergeLists[K comparable, E comparable](current, base, staged map[K][]E, order func(list []E)) {
+	keys := make(map[K]bool, len(staged))
+	for key := range staged {
+		keys[key] = true
+	}
+	for key := range base {
+		keys[key] = true
+	}
+
+	for key := range keys {
+		var added []E
+		for _, entry := range staged[key] {
+			if !slices.Contains(base[key], entry) {
+				added = append(added, entry)
+			}
+		}
+		dropped := func(entry E) bool {
+			return slices.Contains(base[key], entry) && !slices.Contains(staged[key], entry)
+		}
+		if len(added) == 0 && !slices.ContainsFunc(current[key], dropped) {
+			continue
+		}
+
+		merged := append(slices.DeleteFunc(slices.Clone(current[key]), dropped), added...)
+		if len(merged) == 0 {
+			delete(current, key)
+			continue
+		}
+		if order != nil {
+			order(merged)
+		}
+		current[key] = merged
+	}
+}
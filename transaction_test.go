@@ -0,0 +1,268 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer_Transaction(t *testing.T) {
+	newContainer := func(t *testing.T) (*Container, Database) {
+		container := New()
+		original := &mockDatabase{}
+		err := container.Bind(func() Database {
+			return original
+		})
+		require.NoError(t, err)
+		return container, original
+	}
+
+	t.Run("failed transaction leaves the container untouched", func(t *testing.T) {
+		container, original := newContainer(t)
+
+		err := container.Transaction(func(tx *Container) error {
+			err := tx.Bind(func() Database {
+				return &mockDatabase{}
+			})
+			require.NoError(t, err)
+
+			err = tx.Bind(func(db Database) UserService {
+				return &userServiceImpl{db: db}
+			})
+			require.NoError(t, err)
+
+			return errors.New("invalid configuration")
+		})
+		require.EqualError(t, err, "invalid configuration")
+
+		var db Database
+		require.NoError(t, container.Resolve(&db))
+		assert.Same(t, original, db)
+
+		var userService UserService
+		assert.ErrorIs(t, container.Resolve(&userService), ErrBindingNotFound)
+	})
+
+	t.Run("successful transaction applies every change", func(t *testing.T) {
+		container, original := newContainer(t)
+		err := container.BindNamed("replica", func() Database {
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		replacement := &mockDatabase{}
+		err = container.Transaction(func(tx *Container) error {
+			if err := tx.Bind(func() Database { return replacement }); err != nil {
+				return err
+			}
+			if err := tx.Bind(func(db Database) UserService { return &userServiceImpl{db: db} }); err != nil {
+				return err
+			}
+			return tx.UnbindNamed(new(Database), "replica")
+		})
+		require.NoError(t, err)
+
+		var db Database
+		require.NoError(t, container.Resolve(&db))
+		assert.Same(t, replacement, db)
+		assert.NotSame(t, original, db)
+
+		var userService UserService
+		require.NoError(t, container.Resolve(&userService))
+		assert.Same(t, replacement, userService.(*userServiceImpl).db)
+
+		assert.ErrorIs(t, container.ResolveNamed(&db, "replica"), ErrBindingNotFound)
+	})
+
+	t.Run("container is unchanged until the transaction commits", func(t *testing.T) {
+		container, original := newContainer(t)
+
+		err := container.Transaction(func(tx *Container) error {
+			err := tx.Bind(func() Database {
+				return &mockDatabase{}
+			})
+			require.NoError(t, err)
+
+			var db Database
+			require.NoError(t, container.Resolve(&db))
+			assert.Same(t, original, db)
+			return nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("keyed factories, manifest factories and settings are committed", func(t *testing.T) {
+		container, _ := newContainer(t)
+
+		err := container.Transaction(func(tx *Container) error {
+			if err := BindKeyed(tx, func(tenant string) *tenantService { return &tenantService{tenant: tenant} }); err != nil {
+				return err
+			}
+			if err := tx.RegisterFactory("audit", func() Logger { return &loggerImpl{} }); err != nil {
+				return err
+			}
+			tx.OnMissing(func(t reflect.Type, name string) (interface{}, error) {
+				return &mockDatabase{}, nil
+			})
+			return nil
+		})
+		require.NoError(t, err)
+
+		acme, err := ResolveKeyed[string, *tenantService](container, "acme")
+		require.NoError(t, err)
+		assert.Equal(t, "acme", acme.tenant)
+
+		require.NoError(t, container.ImportBindings([]byte(`[{"type":"di.Logger","name":"audit","singleton":true}]`)))
+		var logger Logger
+		require.NoError(t, container.ResolveNamed(&logger, "audit"))
+
+		var replica Database
+		require.NoError(t, container.ResolveNamed(&replica, "replica"))
+	})
+
+	t.Run("singletons constructed in the transaction are stopped by the container", func(t *testing.T) {
+		container, _ := newContainer(t)
+		log := &lifecycleLog{}
+
+		stopped := false
+		err := container.Transaction(func(tx *Container) error {
+			if err := tx.Bind(func() *store { return &store{log: log} }, WithEager()); err != nil {
+				return err
+			}
+			return tx.Bind(func() Logger { return &loggerImpl{} }, WithEager(), WithOnStop(func(any) error {
+				stopped = true
+				return nil
+			}))
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, container.Start(context.Background()))
+		require.NoError(t, container.Stop(context.Background()))
+		assert.Equal(t, []string{"start store", "stop store"}, log.events)
+
+		require.NoError(t, container.Close())
+		assert.True(t, stopped)
+	})
+
+	t.Run("decorators added to the container meanwhile are kept", func(t *testing.T) {
+		container, _ := newContainer(t)
+
+		var trace []string
+		err := container.Transaction(func(tx *Container) error {
+			err := container.Decorate((*Database)(nil), func(db Database) Database {
+				trace = append(trace, "container")
+				return db
+			})
+			require.NoError(t, err)
+
+			return tx.Decorate((*Database)(nil), func(db Database) Database {
+				trace = append(trace, "transaction")
+				return db
+			})
+		})
+		require.NoError(t, err)
+
+		var db Database
+		require.NoError(t, container.Resolve(&db))
+		assert.Equal(t, []string{"container", "transaction"}, trace)
+	})
+
+	t.Run("aborted transaction keeps singletons it resets or replaces", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func() *closableConnection {
+			return &closableConnection{dsn: "primary"}
+		})
+		require.NoError(t, err)
+
+		var cached *closableConnection
+		require.NoError(t, container.Resolve(&cached))
+
+		err = container.Transaction(func(tx *Container) error {
+			require.NoError(t, tx.ResetSingleton(&cached))
+
+			var reconstructed *closableConnection
+			require.NoError(t, tx.Resolve(&reconstructed))
+			assert.NotSame(t, cached, reconstructed)
+
+			err := tx.Replace(&cached, func() *closableConnection {
+				return &closableConnection{dsn: "replica"}
+			})
+			require.NoError(t, err)
+			return errors.New("rollback")
+		})
+		require.EqualError(t, err, "rollback")
+		assert.False(t, cached.closed)
+
+		var conn *closableConnection
+		require.NoError(t, container.Resolve(&conn))
+		assert.Same(t, cached, conn)
+	})
+
+	t.Run("committed transaction closes singletons it resets", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func() *closableConnection {
+			return &closableConnection{dsn: "primary"}
+		})
+		require.NoError(t, err)
+
+		var cached *closableConnection
+		require.NoError(t, container.Resolve(&cached))
+
+		err = container.Transaction(func(tx *Container) error {
+			return tx.ResetSingleton(&cached)
+		})
+		require.NoError(t, err)
+		assert.True(t, cached.closed)
+
+		var conn *closableConnection
+		require.NoError(t, container.Resolve(&conn))
+		assert.NotSame(t, cached, conn)
+	})
+
+	t.Run("aborted transaction stops the singletons it resolved", func(t *testing.T) {
+		container := New()
+		stopped := 0
+		err := container.Bind(func() *closableConnection {
+			return &closableConnection{dsn: "primary"}
+		}, WithOnStop(func(any) error {
+			stopped++
+			return nil
+		}))
+		require.NoError(t, err)
+
+		var resolved *closableConnection
+		err = container.Transaction(func(tx *Container) error {
+			require.NoError(t, tx.Resolve(&resolved))
+			return errors.New("rollback")
+		})
+		require.EqualError(t, err, "rollback")
+		assert.Equal(t, 1, stopped)
+
+		var conn *closableConnection
+		require.NoError(t, container.Resolve(&conn))
+		assert.NotSame(t, resolved, conn)
+
+		require.NoError(t, container.Close())
+		assert.Equal(t, 2, stopped)
+	})
+
+	t.Run("name-aware singletons bound in the transaction", func(t *testing.T) {
+		container := New()
+		err := container.Transaction(func(tx *Container) error {
+			err := tx.Bind(func(name string) Cache {
+				return &namedCache{backend: name}
+			}, WithNameAware())
+			require.NoError(t, err)
+
+			var cache Cache
+			require.NoError(t, tx.ResolveNamed(&cache, "redis"))
+			assert.Equal(t, "redis", cache.Get(""))
+			return nil
+		})
+		require.NoError(t, err)
+	})
+}
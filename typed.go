@@ -0,0 +1,56 @@
+package di
+
+import "fmt"
+
+// ProvideTyped binds a transient factory for T without parameters as the default binding for T.
+// Besides being resolvable like any other binding, the factory is stored in a typed form that Get calls
+// directly, skipping the reflection of Resolve, e.g. for instances created in a tight loop.
+func ProvideTyped[T any](c *Container, factory func() (T, error)) error {
+	if factory == nil {
+		return fmt.Errorf("container: the factory %w", ErrNotAFunction)
+	}
+
+	typed := func() (any, error) {
+		return factory()
+	}
+	return c.Bind(factory, WithTransient(), func(config *bindConfig) {
+		config.typed = typed
+	})
+}
+
+// Get returns an instance of T from the default binding for T. Bindings registered with ProvideTyped
+// are constructed by calling their factory directly, unless T has decorators or the container has an
+// observer or records timings; every other binding is resolved like Resolve would.
+func Get[T any](c *Container) (T, error) {
+	typ := typeOf[T]()
+
+	c.lock.RLock()
+	b, err := c.active(c.bindings[typ][""])
+	direct := err == nil && b != nil && b.typed != nil && len(c.decorators[typ]) == 0
+	c.lock.RUnlock()
+
+	if direct && c.observer.Load() == nil && !c.recordingTimings() {
+		instance, err := b.callTyped()
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+		// A nil interface result is returned as the zero value of T
+		typed, _ := instance.(T)
+		return typed, nil
+	}
+
+	var instance T
+	err = c.Resolve(&instance)
+	return instance, err
+}
+
+// callTyped calls the typed factory of the binding, converting a panic into an error like invoke.
+func (b *binding) callTyped() (instance any, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			instance, err = nil, fmt.Errorf("panic while constructing %s: %v", b.typ, p)
+		}
+	}()
+	return b.typed()
+}
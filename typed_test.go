@@ -0,0 +1,92 @@
+package di
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvideTyped(t *testing.T) {
+	t.Run("Get constructs a new instance per call", func(t *testing.T) {
+		container := New()
+		err := ProvideTyped(container, func() (Database, error) {
+			return &mockDatabase{}, nil
+		})
+		require.NoError(t, err)
+
+		first, err := Get[Database](container)
+		require.NoError(t, err)
+		second, err := Get[Database](container)
+		require.NoError(t, err)
+		assert.NotSame(t, first, second)
+	})
+
+	t.Run("typed binding is resolvable and injectable", func(t *testing.T) {
+		container := New()
+		require.NoError(t, ProvideTyped(container, func() (Database, error) {
+			return &mockDatabase{}, nil
+		}))
+		require.NoError(t, container.Bind(func(db Database) UserService {
+			return &userServiceImpl{db: db}
+		}))
+
+		var userService UserService
+		require.NoError(t, container.Resolve(&userService))
+		assert.NotNil(t, userService.(*userServiceImpl).db)
+	})
+
+	t.Run("nil interface result", func(t *testing.T) {
+		container := New()
+		require.NoError(t, ProvideTyped(container, func() (Database, error) {
+			return nil, nil
+		}))
+
+		db, err := Get[Database](container)
+		require.NoError(t, err)
+		assert.Nil(t, db)
+	})
+
+	t.Run("factory errors and panics are returned", func(t *testing.T) {
+		container := New()
+		require.NoError(t, ProvideTyped(container, func() (Database, error) {
+			return nil, errors.New("connection refused")
+		}))
+		_, err := Get[Database](container)
+		assert.EqualError(t, err, "connection refused")
+
+		require.NoError(t, ProvideTyped(container, func() (UserService, error) {
+			panic("boom")
+		}))
+		_, err = Get[UserService](container)
+		assert.EqualError(t, err, "panic while constructing di.UserService: boom")
+	})
+
+	t.Run("decorators are applied", func(t *testing.T) {
+		container := New()
+		require.NoError(t, ProvideTyped(container, func() (Database, error) {
+			return &mockDatabase{}, nil
+		}))
+		require.NoError(t, container.Decorate(new(Database), func(db Database) Database {
+			return &mockDatabase{connected: true}
+		}))
+
+		db, err := Get[Database](container)
+		require.NoError(t, err)
+		assert.True(t, db.(*mockDatabase).connected)
+	})
+
+	t.Run("Get resolves other bindings reflectively", func(t *testing.T) {
+		container := New()
+		original := &mockDatabase{}
+		require.NoError(t, container.Bind(func() Database { return original }))
+
+		db, err := Get[Database](container)
+		require.NoError(t, err)
+		assert.Same(t, original, db)
+
+		_, err = Get[UserService](container)
+		assert.ErrorIs(t, err, ErrBindingNotFound)
+	})
+}
@@ -0,0 +1,133 @@
+package di
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Validate checks, without instantiating anything, that every parameter of every registered factory,
+// fallback and decorator can be satisfied by the container. It returns a joined error listing every
+// unsatisfiable dependency, or nil if the whole graph can be resolved.
+//
+// Named bindings that no factory can receive are reported as orphan warnings through the container's
+// logger; they do not cause Validate to fail.
+func (c *Container) Validate() error {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	for _, b := range c.orphans() {
+		c.logger.Log(fmt.Sprintf("di: warning: orphaned binding for type %s with name '%s' is never injected", b.typ, b.name))
+	}
+
+	var errs []error
+	for _, b := range c.allBindings() {
+		for j, function := range []any{b.resolver, b.fallback} {
+			if function == nil {
+				continue
+			}
+			funcType := reflect.TypeOf(function)
+			for i := 0; i < funcType.NumIn(); i++ {
+				argType := funcType.In(i)
+				if isResolver := j == 0; isResolver && i < len(b.params) && b.params[i] != "" {
+					if named, err := c.active(c.bindings[argType][b.params[i]]); err != nil || named == nil {
+						errs = append(errs, fmt.Errorf("type %s with name '%s' depends on %s with name '%s': %w", b.typ, b.name, argType, b.params[i], ErrBindingNotFound))
+					}
+				} else if !c.satisfiable(argType) {
+					errs = append(errs, fmt.Errorf("type %s with name '%s' depends on %s: %w", b.typ, b.name, argType, ErrBindingNotFound))
+				}
+			}
+		}
+	}
+
+	for decoratedType, decorators := range c.decorators {
+		for _, d := range decorators {
+			funcType := d.function.Type()
+			for i := 1; i < funcType.NumIn(); i++ {
+				if argType := funcType.In(i); !c.satisfiable(argType) {
+					errs = append(errs, fmt.Errorf("decorator for type %s depends on %s: %w", decoratedType, argType, ErrBindingNotFound))
+				}
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// satisfiable reports whether a parameter of the given type could be injected, mirroring resolveArgument.
+func (c *Container) satisfiable(argType reflect.Type) bool {
+	if argType == contextType || argType == containerType {
+		return true
+	}
+
+	if isLazy(argType) {
+		return c.satisfiable(lazyElem(argType))
+	}
+
+	if isProviders(argType) || isOptional(argType) {
+		return true
+	}
+
+	if b, err := c.active(c.bindings[argType][""]); err == nil && b != nil {
+		return true
+	}
+
+	if b, err := c.autoAddrBinding(argType, ""); err == nil && b != nil {
+		return true
+	}
+
+	if b, err := c.implementationBinding(argType); err == nil && b != nil {
+		return true
+	}
+
+	if isProviderFunc(argType) {
+		return c.satisfiable(argType.Out(0))
+	}
+
+	return (argType.Kind() == reflect.Slice || isNamedMap(argType)) && len(c.orderedBindings(argType.Elem())) > 0
+}
+
+// orphans returns named bindings that no factory, fallback or decorator receives. Named bindings
+// are only injected through []T and map[string]T parameters and WithParamNames, so they are unused unless resolved manually.
+func (c *Container) orphans() []*binding {
+	consumed := make(map[reflect.Type]bool)
+	consume := func(funcType reflect.Type, from int) {
+		for i := from; i < funcType.NumIn(); i++ {
+			if argType := funcType.In(i); isProviders(argType) {
+				consumed[providersElem(argType)] = true
+			} else if argType.Kind() == reflect.Slice || isNamedMap(argType) {
+				consumed[argType.Elem()] = true
+			}
+		}
+	}
+
+	consumedNames := make(map[reflect.Type]map[string]bool)
+	all := c.allBindings()
+	for _, b := range all {
+		for _, function := range []any{b.resolver, b.fallback} {
+			if function != nil {
+				consume(reflect.TypeOf(function), 0)
+			}
+		}
+		for i, name := range b.params {
+			argType := reflect.TypeOf(b.resolver).In(i)
+			if consumedNames[argType] == nil {
+				consumedNames[argType] = make(map[string]bool)
+			}
+			consumedNames[argType][name] = true
+		}
+	}
+	for _, decorators := range c.decorators {
+		for _, d := range decorators {
+			consume(d.function.Type(), 1)
+		}
+	}
+
+	var orphans []*binding
+	for _, b := range all {
+		if b.name != "" && !consumed[b.typ] && !consumedNames[b.typ][b.name] {
+			orphans = append(orphans, b)
+		}
+	}
+	return orphans
+}
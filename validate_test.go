@@ -0,0 +1,150 @@
+package di
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer_Validate(t *testing.T) {
+	t.Run("satisfiable graph", func(t *testing.T) {
+		container := New()
+		constructed := false
+
+		err := container.Bind(func(ctx context.Context) Database {
+			constructed = true
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		err = container.Bind(func(db Database, logger Lazy[Logger]) UserService {
+			constructed = true
+			return &userServiceImpl{db: db}
+		})
+		require.NoError(t, err)
+
+		err = container.Bind(func() Logger {
+			constructed = true
+			return &loggerImpl{}
+		}, WithGroup("loggers"))
+		require.NoError(t, err)
+
+		err = container.Bind(func() Logger {
+			constructed = true
+			return &loggerImpl{}
+		})
+		require.NoError(t, err)
+
+		err = container.Bind(func(loggers []Logger, userService UserService) OrderService {
+			constructed = true
+			return &orderServiceImpl{userService: userService}
+		})
+		require.NoError(t, err)
+
+		assert.NoError(t, container.Validate())
+		assert.False(t, constructed)
+	})
+
+	t.Run("reports every missing dependency", func(t *testing.T) {
+		container := New()
+
+		err := container.Bind(func(db Database) UserService {
+			return &userServiceImpl{db: db}
+		})
+		require.NoError(t, err)
+
+		err = container.BindNamed("audit", func(userService UserService, logger Logger) OrderService {
+			return &orderServiceImpl{userService: userService, logger: logger}
+		})
+		require.NoError(t, err)
+
+		err = container.Validate()
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrBindingNotFound)
+		assert.Contains(t, err.Error(), "type di.UserService with name '' depends on di.Database")
+		assert.Contains(t, err.Error(), "type di.OrderService with name 'audit' depends on di.Logger")
+		assert.NotContains(t, err.Error(), "depends on di.UserService")
+	})
+
+	t.Run("reports missing lazy, slice and decorator dependencies", func(t *testing.T) {
+		container := New()
+
+		err := container.Bind(func(logger Lazy[Logger], dbs []Database) UserService {
+			return &userServiceImpl{}
+		})
+		require.NoError(t, err)
+
+		err = container.Decorate((*UserService)(nil), func(userService UserService, orderService OrderService) UserService {
+			return userService
+		})
+		require.NoError(t, err)
+
+		err = container.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "depends on di.Lazy[")
+		assert.Contains(t, err.Error(), "depends on []di.Database")
+		assert.Contains(t, err.Error(), "decorator for type di.UserService depends on di.OrderService")
+	})
+}
+
+func TestContainer_ValidateOrphans(t *testing.T) {
+	t.Run("orphaned named binding is reported as a warning", func(t *testing.T) {
+		container := New()
+		logger := &recordingLogger{}
+		container.SetLogger(logger)
+
+		err := container.Bind(func() Database {
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		err = container.BindNamed("legacy", func() Database {
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		assert.NoError(t, container.Validate())
+		require.Len(t, logger.messages, 1)
+		assert.Contains(t, logger.messages[0], "orphaned binding for type di.Database with name 'legacy'")
+	})
+
+	t.Run("named bindings consumed by slice parameters are not orphans", func(t *testing.T) {
+		container := New()
+		logger := &recordingLogger{}
+		container.SetLogger(logger)
+
+		err := container.BindNamed("file", func() Logger {
+			return &loggerImpl{}
+		})
+		require.NoError(t, err)
+
+		err = container.Bind(func() Logger {
+			return &loggerImpl{}
+		}, WithGroup("sinks"))
+		require.NoError(t, err)
+
+		err = container.Bind(func(loggers []Logger) Database {
+			return &mockDatabase{}
+		})
+		require.NoError(t, err)
+
+		assert.NoError(t, container.Validate())
+		assert.Empty(t, logger.messages)
+	})
+
+	t.Run("orphan warnings do not hide errors", func(t *testing.T) {
+		container := New()
+		logger := &recordingLogger{}
+		container.SetLogger(logger)
+
+		err := container.BindNamed("legacy", func(db Database) UserService {
+			return &userServiceImpl{db: db}
+		})
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, container.Validate(), ErrBindingNotFound)
+		assert.Len(t, logger.messages, 1)
+	})
+}
@@ -0,0 +1,57 @@
+package di
+
+import "time"
+
+// WithWeak releases the cached singleton instance once it has not been resolved for the given duration,
+// so the garbage collector can reclaim it, and the next resolution constructs a new instance. It suits
+// expensive but reconstructable instances that are only needed now and then and should not be pinned
+// in memory while unused.
+//
+// A weak binding is not a true singleton: instances resolved before and after a release may differ,
+// and released instances are not closed since they may still be in use. Instances that Start, Stop or a
+// WithOnStop hook keep track of stay reachable until then, and only the regular singleton cache is weak,
+// not the caches of WithKeyFunc, WithContextKey or WithScoped bindings.
+func WithWeak(idle time.Duration) BindOption {
+	return func(config *bindConfig) {
+		config.weak = idle
+	}
+}
+
+// start is the reference point of the monotonic times stored in binding.lastUsed.
+var start = time.Now()
+
+// sinceStart returns the monotonic time elapsed since the package was initialized.
+func sinceStart() int64 {
+	return int64(time.Since(start))
+}
+
+// touch records that the cached instance of a weak binding was resolved.
+func (b *binding) touch() {
+	if b.weak > 0 {
+		b.lastUsed.Store(sinceStart())
+	}
+}
+
+// cache stores the singleton instance, arranging for weak bindings to release it once it has been idle too long.
+func (b *binding) cache(val any) {
+	cached := &val
+	b.concrete.Store(cached)
+	if b.weak <= 0 {
+		return
+	}
+
+	b.touch()
+	var expire func()
+	expire = func() {
+		// A newer instance may have replaced this one, e.g. after ResetSingleton
+		if b.concrete.Load() != cached {
+			return
+		}
+		if idle := time.Duration(sinceStart() - b.lastUsed.Load()); idle < b.weak {
+			time.AfterFunc(b.weak-idle, expire)
+			return
+		}
+		b.concrete.CompareAndSwap(cached, nil)
+	}
+	time.AfterFunc(b.weak, expire)
+}
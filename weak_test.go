@@ -0,0 +1,77 @@
+package di
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithWeak(t *testing.T) {
+	t.Run("idle instance is released and reconstructed", func(t *testing.T) {
+		container := New()
+		constructed := 0
+		err := container.Bind(func() Database {
+			constructed++
+			return &mockDatabase{}
+		}, WithWeak(20*time.Millisecond))
+		require.NoError(t, err)
+
+		var db Database
+		require.NoError(t, container.Resolve(&db))
+		require.NoError(t, container.Resolve(&db))
+		assert.Equal(t, 1, constructed)
+
+		assert.Eventually(t, func() bool {
+			instantiated, err := container.IsInstantiated(new(Database))
+			return err == nil && !instantiated
+		}, time.Second, 5*time.Millisecond)
+
+		require.NoError(t, container.Resolve(&db))
+		assert.Equal(t, 2, constructed)
+	})
+
+	t.Run("instance in use is kept", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func() Database {
+			return &mockDatabase{}
+		}, WithWeak(200*time.Millisecond))
+		require.NoError(t, err)
+
+		var first Database
+		require.NoError(t, container.Resolve(&first))
+		for deadline := time.Now().Add(400 * time.Millisecond); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+			var db Database
+			require.NoError(t, container.Resolve(&db))
+			require.Same(t, first, db)
+		}
+	})
+
+	t.Run("explicit reset reconstructs the instance", func(t *testing.T) {
+		container := New()
+		err := container.Bind(func() Database {
+			return &mockDatabase{}
+		}, WithWeak(time.Hour))
+		require.NoError(t, err)
+
+		var first, second Database
+		require.NoError(t, container.Resolve(&first))
+		require.NoError(t, container.ResetSingleton(new(Database)))
+		require.NoError(t, container.Resolve(&second))
+		assert.NotSame(t, first, second)
+	})
+
+	t.Run("regular singletons survive garbage collection", func(t *testing.T) {
+		container := New()
+		require.NoError(t, container.Bind(func() Database { return &mockDatabase{} }))
+
+		var first, second Database
+		require.NoError(t, container.Resolve(&first))
+		runtime.GC()
+		runtime.GC()
+		require.NoError(t, container.Resolve(&second))
+		assert.Same(t, first, second)
+	})
+}